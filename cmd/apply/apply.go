@@ -0,0 +1,59 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/member"
+	"github.com/huhouhua/glctl/cmd/resources/variable"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	applyLong = templates.LongDesc(`
+		Make resources on the server match the ones described in a file.
+
+		The changes are printed before they are applied, use --dry-run to
+		only print them. Resources missing from the file are kept unless
+		--prune is given.`)
+
+	applyExample = templates.Examples(`
+		# Preview the changes to the variables of a project
+		glctl apply variables -f vars.yaml -p group/myapp --dry-run
+
+		# Make the variables of a project exactly the ones of vars.yaml
+		glctl apply variables -f vars.yaml -p group/myapp --prune
+
+		# Make the members of a group exactly the ones of team.yaml
+		glctl apply members -f team.yaml -G group --prune`)
+)
+
+func NewApplyCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "apply",
+		Short:                 "Apply a configuration to resources from a file",
+		Long:                  applyLong,
+		Example:               applyExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(variable.NewApplyVariablesCmd(f, ioStreams))
+	cmd.AddCommand(member.NewApplyMembersCmd(f, ioStreams))
+	return cmd
+}
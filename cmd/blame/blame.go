@@ -0,0 +1,52 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blame
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/file"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	blameLong = templates.LongDesc(`
+		Show the commit and author that last changed every line of a
+		repository file.
+
+		Like git blame, every line is prefixed with the short commit id, the
+		author, the date and the line number. -L limits the output to a range
+		of lines.`)
+
+	blameExample = templates.Examples(`
+		# Annotate every line of a file on the default branch
+		glctl blame myProject deploy/app.yaml
+
+		# Annotate the lines 10 to 40 of a file on a release branch
+		glctl blame myProject deploy/app.yaml --ref=release/1.0 -L 10,40
+
+		# Print the annotations as json
+		glctl blame myProject deploy/app.yaml -L 10,+5 -o json`)
+)
+
+func NewBlameCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := file.NewBlameCmd(f, ioStreams)
+	cmd.Long = blameLong
+	cmd.Example = blameExample
+	return cmd
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/httpcache"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	cacheLong = templates.LongDesc(`
+		Manage the on-disk cache of glctl.
+
+		GET responses are cached under $XDG_CACHE_HOME/glctl when the --cache-ttl
+		flag or cache_ttl in the config file is set, and revalidated with the
+		server using ETags once they are older than the ttl. Only JSON responses
+		up to 1MiB are cached; downloads and the requests waiting for a pipeline,
+		a job or a rebase always go to the server.`)
+
+	clearExample = templates.Examples(`
+		# remove all cached responses and completion results
+		glctl cache clear`)
+)
+
+// ClearOptions is a struct to support cache clear command.
+type ClearOptions struct {
+	dir       string
+	ioStreams genericiooptions.IOStreams
+}
+
+func NewClearOptions(ioStreams genericiooptions.IOStreams) *ClearOptions {
+	return &ClearOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+// NewCacheCmd creates the `cache` command
+func NewCacheCmd(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "cache",
+		Short:                 "Manage the on-disk response cache",
+		Long:                  cacheLong,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(NewClearCmd(ioStreams))
+	return cmd
+}
+
+func NewClearCmd(ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewClearOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "clear",
+		Short:                 "Remove all cached responses",
+		Example:               clearExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *ClearOptions) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+	o.dir, err = cmdutil.CacheDir()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ClearOptions) Validate(cmd *cobra.Command, args []string) error {
+	fi, err := os.Stat(o.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil && !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", o.dir)
+	}
+	return nil
+}
+
+// Run executes the cache clear command.
+func (o *ClearOptions) Run(args []string) error {
+	if err := httpcache.Clear(o.dir); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "cache %s has been cleared\n", o.dir)
+	return nil
+}
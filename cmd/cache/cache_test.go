@@ -0,0 +1,93 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestClear(t *testing.T) {
+	tests := []struct {
+		name           string
+		optionsFunc    func(opt *ClearOptions) error
+		expectedOutput string
+	}{
+		{
+			name: "clear cache",
+			optionsFunc: func(opt *ClearOptions) error {
+				opt.dir = filepath.Join(t.TempDir(), "glctl")
+				return os.MkdirAll(filepath.Join(opt.dir, "http"), 0o700)
+			},
+			expectedOutput: "has been cleared",
+		},
+		{
+			name: "clear missing cache",
+			optionsFunc: func(opt *ClearOptions) error {
+				opt.dir = filepath.Join(t.TempDir(), "missing")
+				return nil
+			},
+			expectedOutput: "has been cleared",
+		},
+		{
+			name: "cache is a file",
+			optionsFunc: func(opt *ClearOptions) error {
+				opt.dir = filepath.Join(t.TempDir(), "file")
+				return os.WriteFile(opt.dir, nil, 0o600)
+			},
+			expectedOutput: "is not a directory",
+		},
+	}
+	for _, tc := range tests {
+		streams := genericiooptions.NewTestIOStreamsForPipe()
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewClearCmd(streams)
+			var cmdOptions = NewClearOptions(streams)
+			if err := tc.optionsFunc(cmdOptions); err != nil {
+				t.Errorf("%s: Invalid test case. Specify expected result.\n", tc.name)
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				var err error
+				if err = cmdOptions.Validate(cmd, nil); err != nil {
+					_, _ = fmt.Fprint(streams.Out, err)
+					return
+				}
+				if err = cmdOptions.Run(nil); err != nil {
+					_, _ = fmt.Fprint(streams.Out, err)
+					return
+				}
+			})
+			cmdtesting.TInfo(out)
+			assert.Containsf(
+				t,
+				out,
+				tc.expectedOutput,
+				"%s : Unexpected output! Expected\n%s\ngot\n%s",
+				tc.name,
+				tc.expectedOutput,
+				out,
+			)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cancel
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/job"
+	"github.com/huhouhua/glctl/cmd/resources/pipeline"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	cancelLong = templates.LongDesc(`
+		Cancel a running job, or the running jobs of a pipeline.`)
+
+	cancelExample = templates.Examples(`
+		# Cancel a job
+		glctl cancel job group/myapp 81234
+
+		# Cancel a pipeline
+		glctl cancel pipeline 4211 -p group/myapp`)
+)
+
+func NewCancelCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "cancel",
+		Short:                 "Cancel a running resource",
+		Long:                  cancelLong,
+		Example:               cancelExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(job.NewCancelJobCmd(f, ioStreams))
+	cmd.AddCommand(pipeline.NewCancelPipelineCmd(f, ioStreams))
+	return cmd
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package close
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/issue"
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	closeLong = templates.LongDesc(`
+		Close a resource without deleting it, it can be reopened later.`)
+
+	closeExample = templates.Examples(`
+		# Close a merge request without merging it
+		glctl close mergerequest group/myapp!12
+
+		# Close an issue
+		glctl close issue group/myapp#3`)
+)
+
+func NewCloseCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "close",
+		Short:                 "Close a resource",
+		Long:                  closeLong,
+		Example:               closeExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(mergerequest.NewCloseMergeRequestCmd(f, ioStreams))
+	cmd.AddCommand(issue.NewCloseIssueCmd(f, ioStreams))
+	return cmd
+}
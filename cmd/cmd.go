@@ -29,16 +29,37 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/huhouhua/glctl/cmd/apply"
+	"github.com/huhouhua/glctl/cmd/approve"
+	"github.com/huhouhua/glctl/cmd/blame"
+	"github.com/huhouhua/glctl/cmd/cache"
+	"github.com/huhouhua/glctl/cmd/cancel"
+	"github.com/huhouhua/glctl/cmd/close"
+	"github.com/huhouhua/glctl/cmd/comment"
 	"github.com/huhouhua/glctl/cmd/completion"
+	"github.com/huhouhua/glctl/cmd/cp"
 	"github.com/huhouhua/glctl/cmd/create"
 	delete "github.com/huhouhua/glctl/cmd/delete"
+	"github.com/huhouhua/glctl/cmd/describe"
+	"github.com/huhouhua/glctl/cmd/diff"
 	"github.com/huhouhua/glctl/cmd/edit"
+	"github.com/huhouhua/glctl/cmd/export"
 	"github.com/huhouhua/glctl/cmd/get"
 	"github.com/huhouhua/glctl/cmd/login"
 	"github.com/huhouhua/glctl/cmd/logout"
+	"github.com/huhouhua/glctl/cmd/logs"
+	"github.com/huhouhua/glctl/cmd/merge"
+	"github.com/huhouhua/glctl/cmd/play"
+	"github.com/huhouhua/glctl/cmd/push"
+	"github.com/huhouhua/glctl/cmd/rebase"
+	"github.com/huhouhua/glctl/cmd/reopen"
 	"github.com/huhouhua/glctl/cmd/replace"
+	"github.com/huhouhua/glctl/cmd/retry"
+	"github.com/huhouhua/glctl/cmd/search"
+	"github.com/huhouhua/glctl/cmd/tag"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 	"github.com/huhouhua/glctl/cmd/version"
+	"github.com/huhouhua/glctl/cmd/wait"
 )
 
 var AuthDoc = `
@@ -124,6 +145,30 @@ func NeGlCtlCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 				edit.NewEditCmd(f, ioStreams),
 				delete.NewDeleteCmd(f, ioStreams),
 				create.NewCreateCmd(f, ioStreams),
+				describe.NewDescribeCmd(f, ioStreams),
+			},
+		},
+		{
+			Message: "Workflow Commands:",
+			Commands: []*cobra.Command{
+				diff.NewDiffCmd(f, ioStreams),
+				comment.NewCommentCmd(f, ioStreams),
+				approve.NewApproveCmd(f, ioStreams),
+				merge.NewMergeCmd(f, ioStreams),
+				rebase.NewRebaseCmd(f, ioStreams),
+				close.NewCloseCmd(f, ioStreams),
+				reopen.NewReopenCmd(f, ioStreams),
+				wait.NewWaitCmd(f, ioStreams),
+				retry.NewRetryCmd(f, ioStreams),
+				cancel.NewCancelCmd(f, ioStreams),
+				play.NewPlayCmd(f, ioStreams),
+				tag.NewTagCmd(f, ioStreams),
+			},
+		},
+		{
+			Message: "Troubleshooting and Debugging Commands:",
+			Commands: []*cobra.Command{
+				logs.NewLogsCmd(f, ioStreams),
 			},
 		},
 		{
@@ -137,12 +182,19 @@ func NeGlCtlCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 			Message: "Advanced Commands:",
 			Commands: []*cobra.Command{
 				replace.NewReplaceCmd(f, ioStreams),
+				push.NewPushCmd(f, ioStreams),
+				apply.NewApplyCmd(f, ioStreams),
+				export.NewExportCmd(f, ioStreams),
+				cp.NewCpCmd(f, ioStreams),
+				blame.NewBlameCmd(f, ioStreams),
+				search.NewSearchCmd(f, ioStreams),
 			},
 		},
 		{
 			Message: "Settings Commands:",
 			Commands: []*cobra.Command{
 				completion.NewCmdCompletion(ioStreams, ""),
+				cache.NewCacheCmd(ioStreams),
 			},
 		},
 	}
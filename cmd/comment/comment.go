@@ -0,0 +1,47 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package comment
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	commentLong = templates.LongDesc(`
+		Comment on a resource, or on a line of the changes of a merge request.`)
+
+	commentExample = templates.Examples(`
+		# Comment on line 42 of a file changed by a merge request
+		glctl comment mergerequest group/myapp!12 --path=cmd/main.go --line=42 -m "This can be nil"`)
+)
+
+func NewCommentCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "comment",
+		Short:                 "Comment on a resource",
+		Long:                  commentLong,
+		Example:               commentExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(mergerequest.NewCommentMergeRequestCmd(f, ioStreams))
+	return cmd
+}
@@ -49,6 +49,10 @@ var (
 		completion of glctl commands.  This can be done by sourcing it from
 		the .bash_profile.
 
+		Project paths, group paths, branch names and repository paths are
+		completed from the server, and the results are cached for a short
+		time under $XDG_CACHE_HOME/glctl/completion.
+
 		Detailed instructions on how to do this are available here:
 
 		Note for zsh users: [1] zsh completions are only supported in versions of zsh >= 5.2`)
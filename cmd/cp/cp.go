@@ -0,0 +1,56 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cp
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/file"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	cpLong = templates.LongDesc(`
+		Copy files and directories between the local filesystem and a repository.
+
+		A repository path is written as PROJECT:REF:PATH, leave REF empty for the
+		default branch and PATH empty for the repository root. Directories are
+		copied recursively, downloads fetch the raw file or the repository
+		archive and uploads are pushed as a single commit. Binary files are sent
+		base64 encoded and the executable bit is kept in both directions.`)
+
+	cpExample = templates.Examples(`
+		# Download a file of the main branch
+		glctl cp group1/project1:main:deploy/values.yaml ./values.yaml
+
+		# Download a directory of the default branch
+		glctl cp group1/project1::deploy ./deploy
+
+		# Upload a file, a trailing slash copies it into the directory
+		glctl cp ./values.yaml group1/project1:main:deploy/
+
+		# Upload a directory into the repository root as one commit
+		glctl cp ./deploy group1/project1:main: -m "update deploy manifests"`)
+)
+
+func NewCpCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := file.NewCopyCmd(f, ioStreams)
+	cmd.Long = cpLong
+	cmd.Example = cpExample
+	return cmd
+}
@@ -23,7 +23,14 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/resources/branch"
 	"github.com/huhouhua/glctl/cmd/resources/group"
+	"github.com/huhouhua/glctl/cmd/resources/issue"
+	"github.com/huhouhua/glctl/cmd/resources/member"
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	"github.com/huhouhua/glctl/cmd/resources/pipeline"
 	"github.com/huhouhua/glctl/cmd/resources/project"
+	"github.com/huhouhua/glctl/cmd/resources/release"
+	"github.com/huhouhua/glctl/cmd/resources/tag"
+	"github.com/huhouhua/glctl/cmd/resources/variable"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 )
 
@@ -57,5 +64,12 @@ func NewCreateCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobr
 	cmd.AddCommand(group.NewCreateGroupCmd(f, ioStreams))
 	cmd.AddCommand(project.NewCreateProjectCmd(f, ioStreams))
 	cmd.AddCommand(branch.NewCreateBranchCmd(f, ioStreams))
+	cmd.AddCommand(mergerequest.NewCreateMergeRequestCmd(f, ioStreams))
+	cmd.AddCommand(issue.NewCreateIssueCmd(f, ioStreams))
+	cmd.AddCommand(pipeline.NewCreatePipelineCmd(f, ioStreams))
+	cmd.AddCommand(variable.NewCreateVariableCmd(f, ioStreams))
+	cmd.AddCommand(tag.NewCreateTagCmd(f, ioStreams))
+	cmd.AddCommand(release.NewCreateReleaseCmd(f, ioStreams))
+	cmd.AddCommand(member.NewCreateMemberCmd(f, ioStreams))
 	return cmd
 }
@@ -22,7 +22,12 @@ import (
 	"github.com/huhouhua/glctl/cmd/resources/branch"
 	"github.com/huhouhua/glctl/cmd/resources/file"
 	"github.com/huhouhua/glctl/cmd/resources/group"
+	"github.com/huhouhua/glctl/cmd/resources/member"
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
 	"github.com/huhouhua/glctl/cmd/resources/project"
+	"github.com/huhouhua/glctl/cmd/resources/release"
+	"github.com/huhouhua/glctl/cmd/resources/tag"
+	"github.com/huhouhua/glctl/cmd/resources/variable"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 )
 
@@ -39,5 +44,10 @@ func NewDeleteCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobr
 	cmd.AddCommand(project.NewDeleteProjectCmd(f, ioStreams))
 	cmd.AddCommand(branch.NewDeleteBranchCmd(f, ioStreams))
 	cmd.AddCommand(file.NewDeleteFilesCmd(f, ioStreams))
+	cmd.AddCommand(mergerequest.NewDeleteMergeRequestCmd(f, ioStreams))
+	cmd.AddCommand(variable.NewDeleteVariableCmd(f, ioStreams))
+	cmd.AddCommand(tag.NewDeleteTagCmd(f, ioStreams))
+	cmd.AddCommand(release.NewDeleteReleaseCmd(f, ioStreams))
+	cmd.AddCommand(member.NewDeleteMemberCmd(f, ioStreams))
 	return cmd
 }
@@ -0,0 +1,47 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	describeLong = templates.LongDesc(`
+		Show the details of a resource along with its related resources, such as the pipeline and the approvals of a merge request.`)
+
+	describeExample = templates.Examples(`
+		# Describe a merge request
+		glctl describe mergerequest group/myapp!12`)
+)
+
+func NewDescribeCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "describe",
+		Short:                 "Show the details of a resource",
+		Long:                  describeLong,
+		Example:               describeExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(mergerequest.NewDescribeMergeRequestCmd(f, ioStreams))
+	return cmd
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	diffLong = templates.LongDesc(`
+		Show the changes of a resource as a colored unified diff.
+
+		The threads of a merge request are shown at the lines they were started on.`)
+
+	diffExample = templates.Examples(`
+		# Show the changes of a merge request with its threads
+		glctl diff mergerequest group/myapp!12`)
+)
+
+func NewDiffCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "diff",
+		Short:                 "Show the changes of a resource",
+		Long:                  diffLong,
+		Example:               diffExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(mergerequest.NewDiffMergeRequestCmd(f, ioStreams))
+	return cmd
+}
@@ -22,6 +22,12 @@ import (
 	"github.com/huhouhua/glctl/cmd/resources/branch"
 	"github.com/huhouhua/glctl/cmd/resources/file"
 	"github.com/huhouhua/glctl/cmd/resources/group"
+	"github.com/huhouhua/glctl/cmd/resources/issue"
+	"github.com/huhouhua/glctl/cmd/resources/member"
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	"github.com/huhouhua/glctl/cmd/resources/project"
+	"github.com/huhouhua/glctl/cmd/resources/tag"
+	"github.com/huhouhua/glctl/cmd/resources/variable"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 )
 
@@ -35,7 +41,13 @@ func NewEditCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.
 		DisableFlagsInUseLine: true,
 	}
 	cmd.AddCommand(group.NewEditGroupCmd(f, ioStreams))
+	cmd.AddCommand(project.NewEditProjectCmd(f, ioStreams))
 	cmd.AddCommand(branch.NewEditBranchCmd(f, ioStreams))
 	cmd.AddCommand(file.NewEditFileCmd(f, ioStreams))
+	cmd.AddCommand(mergerequest.NewEditMergeRequestCmd(f, ioStreams))
+	cmd.AddCommand(issue.NewEditIssueCmd(f, ioStreams))
+	cmd.AddCommand(variable.NewEditVariableCmd(f, ioStreams))
+	cmd.AddCommand(tag.NewEditTagCmd(f, ioStreams))
+	cmd.AddCommand(member.NewEditMemberCmd(f, ioStreams))
 	return cmd
 }
@@ -0,0 +1,52 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/variable"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	exportLong = templates.LongDesc(`
+		Export resources in a format other tools and glctl apply can read.
+
+		Unlike get, values are exported as they are, masked ones included.`)
+
+	exportExample = templates.Examples(`
+		# Export the variables of a project to a .env file
+		glctl export variables -p group/myapp > .env
+
+		# Export the variables of a group as yaml
+		glctl export variables -G group -o yaml > vars.yaml`)
+)
+
+func NewExportCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "export",
+		Short:                 "Export resources to a file format",
+		Long:                  exportLong,
+		Example:               exportExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(variable.NewExportVariablesCmd(f, ioStreams))
+	return cmd
+}
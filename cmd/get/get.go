@@ -22,7 +22,15 @@ import (
 	"github.com/huhouhua/glctl/cmd/resources/branch"
 	"github.com/huhouhua/glctl/cmd/resources/file"
 	"github.com/huhouhua/glctl/cmd/resources/group"
+	"github.com/huhouhua/glctl/cmd/resources/issue"
+	"github.com/huhouhua/glctl/cmd/resources/job"
+	"github.com/huhouhua/glctl/cmd/resources/member"
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	"github.com/huhouhua/glctl/cmd/resources/pipeline"
 	"github.com/huhouhua/glctl/cmd/resources/project"
+	"github.com/huhouhua/glctl/cmd/resources/release"
+	"github.com/huhouhua/glctl/cmd/resources/tag"
+	"github.com/huhouhua/glctl/cmd/resources/variable"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 )
 
@@ -41,5 +49,15 @@ func NewGetCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.C
 	cmd.AddCommand(group.NewGetGroupsCmd(f, ioStreams))
 	cmd.AddCommand(branch.NewGetBranchesCmd(f, ioStreams))
 	cmd.AddCommand(file.NewGetFilesCmd(f, ioStreams))
+	cmd.AddCommand(file.NewGetArchiveCmd(f, ioStreams))
+	cmd.AddCommand(file.NewGetFileHistoryCmd(f, ioStreams))
+	cmd.AddCommand(mergerequest.NewGetMergeRequestsCmd(f, ioStreams))
+	cmd.AddCommand(issue.NewGetIssuesCmd(f, ioStreams))
+	cmd.AddCommand(pipeline.NewGetPipelinesCmd(f, ioStreams))
+	cmd.AddCommand(job.NewGetArtifactsCmd(f, ioStreams))
+	cmd.AddCommand(variable.NewGetVariablesCmd(f, ioStreams))
+	cmd.AddCommand(tag.NewGetTagsCmd(f, ioStreams))
+	cmd.AddCommand(release.NewGetReleasesCmd(f, ioStreams))
+	cmd.AddCommand(member.NewGetMembersCmd(f, ioStreams))
 	return cmd
 }
@@ -0,0 +1,47 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package play
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/job"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	playLong = templates.LongDesc(`
+		Start a manual job.`)
+
+	playExample = templates.Examples(`
+		# Deploy by starting the manual job of a pipeline
+		glctl play job group/myapp 81240`)
+)
+
+func NewPlayCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "play",
+		Short:                 "Start a manual job",
+		Long:                  playLong,
+		Example:               playExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(job.NewPlayJobCmd(f, ioStreams))
+	return cmd
+}
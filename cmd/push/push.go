@@ -0,0 +1,53 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/file"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	pushLong = templates.LongDesc(`
+		Push local files to a repository branch.
+
+		The local directory is compared with the repository tree and every
+		created, updated, moved or deleted file is sent as a single commit.`)
+
+	pushExample = templates.Examples(`
+		# Push the files of ./deploy to the deploy directory of the main branch
+		glctl push files ./deploy --project=myproject --branch=main --prefix=deploy/
+
+		# Preview the commit that would make the deploy directory mirror ./deploy
+		glctl push files ./deploy --project=myproject --branch=main --prefix=deploy/ --delete-missing --dry-run`)
+)
+
+func NewPushCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "push",
+		Short:                 "Push local files to a repository branch",
+		Long:                  pushLong,
+		Example:               pushExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(file.NewPushFilesCmd(f, ioStreams))
+	return cmd
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/mergerequest"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	rebaseLong = templates.LongDesc(`
+		Rebase the source branch of a merge request onto its target branch.
+
+		The rebase runs on the server, the command waits until it is done unless
+		--wait=false is given.`)
+
+	rebaseExample = templates.Examples(`
+		# Rebase a merge request
+		glctl rebase mergerequest group/myapp!12`)
+)
+
+func NewRebaseCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "rebase",
+		Short:                 "Rebase a merge request onto its target branch",
+		Long:                  rebaseLong,
+		Example:               rebaseExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(mergerequest.NewRebaseMergeRequestCmd(f, ioStreams))
+	return cmd
+}
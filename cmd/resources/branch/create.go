@@ -27,6 +27,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -73,6 +74,8 @@ func NewCreateBranchCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		SuggestFor: []string{},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
 	return cmd
 }
 
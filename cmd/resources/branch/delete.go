@@ -26,6 +26,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -58,6 +59,7 @@ func NewDeleteBranchCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Args:                  require.ExactArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.BranchCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -67,6 +69,7 @@ func NewDeleteBranchCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 	}
 	cmdutil.AddProjectVarPFlag(cmd, &o.project)
 	validate.VerifyMarkFlagRequired(cmd, "project")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
 	return cmd
 }
 
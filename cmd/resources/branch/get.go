@@ -26,6 +26,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 )
 
 type ListOptions struct {
@@ -33,6 +34,8 @@ type ListOptions struct {
 	Out          string
 	branch       *gitlab.ListBranchesOptions
 	All          bool
+	Limit        int64
+	ChunkSize    int64
 	ioStreams    genericiooptions.IOStreams
 }
 
@@ -48,6 +51,9 @@ glctl get branch 100
 
 # get all branch
 glctl get branch 100 -A
+
+# get the first 500 branches, 50 per request
+glctl get branch 100 -A --limit=500 --chunk-size=50
 `)
 )
 
@@ -60,8 +66,9 @@ func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
 				PerPage: 10,
 			},
 		},
-		All: false,
-		Out: "simple",
+		All:       false,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
 	}
 }
 func NewGetBranchesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
@@ -74,6 +81,7 @@ func NewGetBranchesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
 		Args:                  require.ExactArgs(1),
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -89,6 +97,8 @@ func NewGetBranchesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 func (o *ListOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddPaginationVarFlags(cmd, &o.branch.ListOptions)
 	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
 	f := cmd.Flags()
 	f.BoolVarP(
 		&o.All,
@@ -116,21 +126,17 @@ func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
 
 // Run executes a list subcommand using the specified options.
 func (o *ListOptions) Run(args []string) error {
-	var branches []*gitlab.Branch
 	if o.All {
-		o.branch.PerPage = 100
+		o.branch.PerPage = o.ChunkSize
 		o.branch.Page = 1
 	}
-	for {
-		list, _, err := o.gitlabClient.Branches.ListBranches(args[0], o.branch)
-		if err != nil {
-			return nil
-		}
-		branches = append(branches, list...)
-		if cap(list) == 0 || !o.All {
-			break
-		}
-		o.branch.Page++
+	printer := cmdutil.NewBranchPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+			return o.gitlabClient.Branches.ListBranches(args[0], o.branch, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
 	}
-	return cmdutil.PrintBranchOut(o.Out, o.ioStreams.Out, branches...)
+	return printer.Flush()
 }
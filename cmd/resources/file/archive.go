@@ -0,0 +1,249 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/archive"
+	"github.com/huhouhua/glctl/pkg/util/progress"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+	"github.com/huhouhua/glctl/pkg/util/term"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+var archiveFormats = []string{"tar.gz", "tar", "zip"}
+
+type ArchiveOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	Ref          string
+	Path         string
+	Format       string
+	Output       string
+	Extract      string
+	ioStreams    genericiooptions.IOStreams
+}
+
+func NewArchiveOptions(ioStreams genericiooptions.IOStreams) *ArchiveOptions {
+	return &ArchiveOptions{
+		ioStreams: ioStreams,
+		Format:    "tar.gz",
+	}
+}
+
+var (
+	getArchiveExample = templates.Examples(`
+# download the archive of the default branch
+glctl get archive myProject
+
+# download a subdirectory of the main branch as a zip file
+glctl get archive myProject --ref=main --path=deploy --format=zip -o deploy.zip
+
+# write the archive to stdout
+glctl get archive myProject --ref=main -o - | tar -tzf -
+
+# extract a subdirectory of the main branch into ./deploy
+glctl get archive myProject --ref=main --path=deploy --extract=./deploy`)
+)
+
+func NewGetArchiveCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewArchiveOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "archive",
+		Aliases:               []string{"archives"},
+		Short:                 "download the archive of a repository or one of its directories",
+		Example:               getArchiveExample,
+		DisableFlagsInUseLine: true,
+		Args:                  require.ExactArgs(1),
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("path", completion.FilePathCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("format",
+		cobra.FixedCompletions(archiveFormats, cobra.ShellCompDirectiveNoFileComp)))
+	return cmd
+}
+
+func (o *ArchiveOptions) AddFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringVar(&o.Ref, "ref", o.Ref, "The name of a repository branch, tag or commit or, if not given, the default branch.")
+	f.StringVar(&o.Path, "path", o.Path, "The subdirectory of the repository to download.")
+	f.StringVar(&o.Format, "format", o.Format, "The archive format. One of: "+strings.Join(archiveFormats, "|"))
+	f.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"The file the archive is written to, - writes it to stdout. Default is named after the project and ref.",
+	)
+	f.StringVar(&o.Extract, "extract", o.Extract, "Extract the archive into this directory instead of keeping it.")
+}
+
+// Complete completes all the required options.
+func (o *ArchiveOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.project = args[0]
+	}
+	o.Path = cleanRepositoryPath(o.Path)
+	if o.Output == "" && o.Extract == "" {
+		name := path.Base(o.project)
+		for _, s := range []string{o.Ref, o.Path} {
+			if s != "" {
+				name += "-" + strings.ReplaceAll(s, "/", "-")
+			}
+		}
+		o.Output = name + "." + o.Format
+	}
+	gitlabClient, err := f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.gitlabClient = gitlabClient
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ArchiveOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return fmt.Errorf("please enter project name and id")
+	}
+	var supported bool
+	for _, format := range archiveFormats {
+		supported = supported || o.Format == format
+	}
+	if !supported {
+		return cmdutil.UsageErrorf(cmd, "unsupported archive format %s, must be one of: %s",
+			o.Format, strings.Join(archiveFormats, "|"))
+	}
+	if o.Output == "-" && o.Extract != "" {
+		return cmdutil.UsageErrorf(cmd, "--extract can not be used when writing the archive to stdout")
+	}
+	return nil
+}
+
+// Run executes a get subcommand using the specified options.
+func (o *ArchiveOptions) Run(args []string) error {
+	if o.Output == "-" {
+		return o.download(o.ioStreams.Out)
+	}
+	name := o.Output
+	if name == "" {
+		tmp, err := os.CreateTemp("", "glctl-archive-*."+o.Format)
+		if err != nil {
+			return err
+		}
+		name = tmp.Name()
+		_ = tmp.Close()
+		defer os.Remove(name)
+	}
+	if err := o.save(name); err != nil {
+		return err
+	}
+	if o.Extract == "" {
+		_, err := fmt.Fprintf(o.ioStreams.Out, "archive saved to %s\n", name)
+		return err
+	}
+	if err := o.extract(name); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(o.ioStreams.Out, "archive extracted to %s\n", o.Extract)
+	return err
+}
+
+// save streams the archive into the file name, which is removed again when
+// the download fails.
+func (o *ArchiveOptions) save(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	err = o.download(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(name)
+	}
+	return err
+}
+
+func (o *ArchiveOptions) download(w io.Writer) error {
+	opt := &gitlab.ArchiveOptions{
+		Format: pointer.ToString(o.Format),
+	}
+	if o.Ref != "" {
+		opt.SHA = pointer.ToString(o.Ref)
+	}
+	if o.Path != "" {
+		opt.Path = pointer.ToString(o.Path)
+	}
+	// the progress goes to stderr so it never ends up in a redirected archive.
+	out := io.Discard
+	if term.IsTerminal(o.ioStreams.ErrOut) {
+		out = o.ioStreams.ErrOut
+	}
+	bar := progress.NewBar(out, 0, fmt.Sprintf(" downloading %s", o.project))
+	if _, err := o.gitlabClient.Repositories.StreamArchive(o.project, io.MultiWriter(w, bar), opt, cmdutil.SkipCache()); err != nil {
+		return err
+	}
+	bar.Done()
+	return nil
+}
+
+func (o *ArchiveOptions) extract(name string) error {
+	if err := os.MkdirAll(o.Extract, 0o755); err != nil {
+		return err
+	}
+	rename := archiveRename(o.Path)
+	if o.Format == "zip" {
+		return archive.Unzip(name, o.Extract, rename)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if o.Format == "tar.gz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return archive.Untar(r, o.Extract, rename)
+}
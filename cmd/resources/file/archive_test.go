@@ -0,0 +1,86 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetArchive(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *ArchiveOptions)
+		wantError   error
+	}{{
+		name: "download archive",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ArchiveOptions) {
+			opt.Output = filepath.Join(dir, "project13.tar.gz")
+		},
+		wantError: nil,
+	}, {
+		name: "extract zip archive of a subdirectory",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ArchiveOptions) {
+			opt.Ref = "main"
+			opt.Path = "test"
+			opt.Format = "zip"
+			opt.Extract = filepath.Join(dir, "test")
+		},
+		wantError: nil,
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewGetArchiveCmd(factory, streams)
+			var cmdOptions = NewArchiveOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+func TestGetArchiveArgs(t *testing.T) {
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	cmd := NewGetArchiveCmd(cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter()), streams)
+	assert.NoError(t, cmd.Args(cmd, []string{"Group2/SubGroup3/Project13"}))
+	// a second argument, e.g. a mistyped output path, is not ignored.
+	assert.ErrorContains(t, cmd.Args(cmd, []string{"Group2/SubGroup3/Project13", "project13.tar.gz"}),
+		"requires 1 argument")
+}
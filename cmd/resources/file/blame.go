@@ -0,0 +1,176 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type BlameOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	path         string
+	lines        *lineRange
+	Ref          string
+	Lines        string
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+func NewBlameOptions(ioStreams genericiooptions.IOStreams) *BlameOptions {
+	return &BlameOptions{
+		ioStreams: ioStreams,
+		Out:       "simple",
+	}
+}
+
+func NewBlameCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewBlameOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "blame <project> <path>",
+		Short:                 "Show what revision and author last modified each line of a file",
+		Args:                  require.ExactArgs(2),
+		DisableFlagsInUseLine: true,
+		ValidArgsFunction:     completion.ProjectAndPathArgs(f),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+func (o *BlameOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	f := cmd.Flags()
+	f.StringVar(&o.Ref, "ref", o.Ref, "The name of a repository branch or tag or, if not given, the default branch.")
+	f.StringVarP(&o.Lines, "lines", "L", o.Lines, "Only the lines start,end, start,+count or start, of the file, e.g. -L 10,40")
+}
+
+// Complete completes all the required options.
+func (o *BlameOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	if len(args) > 1 {
+		o.project, o.path = args[0], args[1]
+	}
+	if o.lines, err = parseLineRange(o.Lines); err != nil {
+		return err
+	}
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *BlameOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" || strings.TrimSpace(o.path) == "" {
+		return cmdutil.UsageErrorf(cmd, "please enter the project and the path of the file")
+	}
+	return nil
+}
+
+// blameLine is a line of a file with the commit that last changed it.
+type blameLine struct {
+	Line    int64                       `json:"line"    yaml:"line"`
+	Content string                      `json:"content" yaml:"content"`
+	Commit  gitlab.FileBlameRangeCommit `json:"commit"  yaml:"commit"`
+}
+
+// Run executes a blame subcommand using the specified options.
+func (o *BlameOptions) Run(args []string) error {
+	lines, err := blame(o.gitlabClient, o.project, o.path, o.Ref, o.lines)
+	if err != nil {
+		return err
+	}
+	if o.Out != cmdutil.JSON && o.Out != cmdutil.YAML {
+		return printBlame(o.ioStreams, lines)
+	}
+	printer := cmdutil.NewListPrinter[*blameLine](o.Out, o.ioStreams.Out, nil, nil)
+	if err = printer.PrintChunk(lines); err != nil {
+		return err
+	}
+	return printer.Flush()
+}
+
+// blame returns the lines of the file at ref in the range, the default branch
+// is used without a ref.
+func blame(client *gitlab.Client, project, path, ref string, lines *lineRange) ([]*blameLine, error) {
+	if ref == "" {
+		p, _, err := client.Projects.GetProject(project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		ref = p.DefaultBranch
+	}
+	opt := &gitlab.GetFileBlameOptions{Ref: pointer.ToString(ref)}
+	line := int64(1)
+	// the API takes a range with both ends only, an open range is cut here.
+	if lines != nil && lines.End > 0 {
+		opt.RangeStart, opt.RangeEnd = pointer.ToInt64(lines.Start), pointer.ToInt64(lines.End)
+		line = lines.Start
+	}
+	ranges, _, err := client.RepositoryFiles.GetFileBlame(project, path, opt)
+	if err != nil {
+		return nil, err
+	}
+	var result []*blameLine
+	for _, r := range ranges {
+		for _, content := range r.Lines {
+			if lines.contains(line) {
+				result = append(result, &blameLine{Line: line, Content: content, Commit: r.Commit})
+			}
+			line++
+		}
+	}
+	return result, nil
+}
+
+// printBlame prints the lines annotated like git blame.
+func printBlame(ioStreams genericiooptions.IOStreams, lines []*blameLine) error {
+	if len(lines) == 0 {
+		_, err := fmt.Fprintln(ioStreams.ErrOut, "no lines in the given range")
+		return err
+	}
+	author, number := 0, len(fmt.Sprint(lines[len(lines)-1].Line))
+	for _, l := range lines {
+		author = max(author, utf8.RuneCountInString(l.Commit.AuthorName))
+	}
+	for _, l := range lines {
+		date := ""
+		if l.Commit.AuthoredDate != nil {
+			date = l.Commit.AuthoredDate.Format("2006-01-02")
+		}
+		_, err := fmt.Fprintf(ioStreams.Out, "%s (%-*s %s %*d) %s\n",
+			shortID(l.Commit.ID), author, l.Commit.AuthorName, date, number, l.Line, l.Content)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/archive"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+// remotePath is a repository path given as project:ref:path, an empty ref
+// stands for the default branch and an empty path for the repository root.
+type remotePath struct {
+	project string
+	ref     string
+	path    string
+	// dir is set when the path was given with a trailing slash.
+	dir bool
+}
+
+func (r *remotePath) String() string {
+	return fmt.Sprintf("%s:%s:%s", r.project, r.ref, r.path)
+}
+
+// parseRemotePath parses project:ref:path, paths starting with ".", "/" or a
+// volume name are always local so they can contain colons.
+func parseRemotePath(s string) (*remotePath, bool) {
+	if strings.HasPrefix(s, ".") || filepath.IsAbs(s) || filepath.VolumeName(s) != "" {
+		return nil, false
+	}
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, false
+	}
+	return &remotePath{
+		project: parts[0],
+		ref:     parts[1],
+		path:    cleanRepositoryPath(parts[2]),
+		dir:     strings.HasSuffix(parts[2], "/"),
+	}, true
+}
+
+type CopyOptions struct {
+	gitlabClient *gitlab.Client
+	remote       *remotePath
+	local        string
+	upload       bool
+	Message      string
+	ioStreams    genericiooptions.IOStreams
+}
+
+func NewCopyOptions(ioStreams genericiooptions.IOStreams) *CopyOptions {
+	return &CopyOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewCopyCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCopyOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "cp <src> <dest>",
+		Short:                 "Copy files and directories to and from repositories",
+		Args:                  require.ExactArgs(2),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *CopyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Message, "message", "m", o.Message, "The commit message used when uploading.")
+}
+
+// Complete completes all the required options.
+func (o *CopyOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return nil
+	}
+	if remote, ok := parseRemotePath(args[0]); ok {
+		o.remote, o.local = remote, args[1]
+	}
+	if remote, ok := parseRemotePath(args[1]); ok {
+		o.remote, o.local, o.upload = remote, args[0], true
+	}
+	if strings.TrimSpace(o.Message) == "" {
+		o.Message = fmt.Sprintf("copy %s from glctl command line", filepath.Base(filepath.Clean(o.local)))
+	}
+	gitlabClient, err := f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.gitlabClient = gitlabClient
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CopyOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return cmdutil.UsageErrorf(cmd, "source and destination are required")
+	}
+	_, srcRemote := parseRemotePath(args[0])
+	_, destRemote := parseRemotePath(args[1])
+	if srcRemote == destRemote {
+		return cmdutil.UsageErrorf(cmd, "exactly one of source and destination must be a PROJECT:REF:PATH")
+	}
+	if o.upload {
+		if _, err := os.Stat(o.local); os.IsNotExist(err) {
+			return fmt.Errorf("the path %s does not exist", o.local)
+		}
+	}
+	return nil
+}
+
+// Run executes a copy command using the specified options.
+func (o *CopyOptions) Run(args []string) error {
+	if o.remote.ref == "" {
+		project, _, err := o.gitlabClient.Projects.GetProject(o.remote.project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return err
+		}
+		o.remote.ref = project.DefaultBranch
+	}
+	if o.upload {
+		return o.uploadFiles()
+	}
+	return o.download()
+}
+
+func (o *CopyOptions) download() error {
+	node, err := o.remoteNode(o.remote.path)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("%s does not exist", o.remote)
+	}
+	name := path.Base(o.remote.path)
+	if o.remote.path == "" {
+		name = path.Base(o.remote.project)
+	}
+	target := o.local
+	if fi, err := os.Stat(target); err == nil && fi.IsDir() {
+		target = filepath.Join(target, name)
+	}
+	if node.Type == "blob" {
+		err = o.downloadFile(node, target)
+	} else {
+		err = o.downloadDir(target)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.ioStreams.Out, "%s copied to %s\n", o.remote, target)
+	return err
+}
+
+func (o *CopyOptions) downloadFile(node *gitlab.TreeNode, target string) error {
+	content, _, err := o.gitlabClient.RepositoryFiles.GetRawFile(o.remote.project, node.Path, &gitlab.GetRawFileOptions{
+		Ref: pointer.ToString(o.remote.ref),
+	}, cmdutil.SkipCache())
+	if err != nil {
+		return err
+	}
+	var mode os.FileMode = 0o644
+	if node.Mode == executableFileMode {
+		mode = 0o755
+	}
+	if err = os.WriteFile(target, content, mode); err != nil {
+		return err
+	}
+	return os.Chmod(target, mode)
+}
+
+// downloadDir streams the repository archive of the remote directory and
+// extracts it into target while it downloads.
+func (o *CopyOptions) downloadDir(target string) error {
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return err
+	}
+	opt := &gitlab.ArchiveOptions{
+		Format: pointer.ToString("tar.gz"),
+		SHA:    pointer.ToString(o.remote.ref),
+	}
+	if o.remote.path != "" {
+		opt.Path = pointer.ToString(o.remote.path)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := o.gitlabClient.Repositories.StreamArchive(o.remote.project, pw, opt, cmdutil.SkipCache())
+		_ = pw.CloseWithError(err)
+	}()
+	err := o.extract(pr, target)
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+func (o *CopyOptions) extract(r io.Reader, target string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return archive.Untar(gz, target, archiveRename(o.remote.path))
+}
+
+// archiveRename maps the entries of a repository archive to their path
+// relative to the archived repository directory p.
+func archiveRename(p string) archive.RenameFunc {
+	strip := archive.StripComponents(1)
+	return func(name string) (string, bool) {
+		// archive entries are prefixed with a top level directory named
+		// after the project and ref, followed by the full repository path.
+		name, ok := strip(name)
+		if !ok || p == "" {
+			return name, ok
+		}
+		rel := relativeRepositoryPath(p, name)
+		return rel, rel != name
+	}
+}
+
+// uploadFiles pushes the local file or directory as one commit, like cp a
+// source is copied into the destination when that is an existing directory.
+func (o *CopyOptions) uploadFiles() error {
+	fi, err := os.Stat(o.local)
+	if err != nil {
+		return err
+	}
+	node, err := o.remoteNode(o.remote.path)
+	if err != nil {
+		return err
+	}
+	target := o.remote.path
+	if o.remote.dir || (node != nil && node.Type == "tree") {
+		target = path.Join(target, filepath.Base(filepath.Clean(o.local)))
+	}
+	var (
+		local  map[string]*localFile
+		remote map[string]*gitlab.TreeNode
+		prefix = target
+	)
+	if fi.IsDir() {
+		if local, err = readLocalTree(o.local); err != nil {
+			return err
+		}
+		if remote, err = listRemoteTree(o.gitlabClient, o.remote.project, o.remote.ref, prefix); err != nil {
+			return err
+		}
+	} else {
+		prefix = cleanRepositoryPath(path.Dir(target))
+		name := path.Base(target)
+		file, err := readLocalFile(o.local, name)
+		if err != nil {
+			return err
+		}
+		local = map[string]*localFile{name: file}
+		remote = map[string]*gitlab.TreeNode{}
+		if target != o.remote.path {
+			if node, err = o.remoteNode(target); err != nil {
+				return err
+			}
+		}
+		if node != nil && node.Type == "blob" {
+			remote[name] = node
+		}
+	}
+	actions := commitActions(local, remote, prefix, false)
+	if len(actions) == 0 {
+		_, err = fmt.Fprintf(o.ioStreams.Out, "%s is up to date, nothing to copy\n", o.remote)
+		return err
+	}
+	if err = printCommitActions(o.ioStreams, actions); err != nil {
+		return err
+	}
+	commit, _, err := o.gitlabClient.Commits.CreateCommit(o.remote.project, &gitlab.CreateCommitOptions{
+		Branch:        pointer.ToString(o.remote.ref),
+		CommitMessage: pointer.ToString(o.Message),
+		Actions:       actions,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.ioStreams.Out, "commit %s pushed to branch %s with %d actions\n",
+		commit.ShortID, o.remote.ref, len(actions))
+	return err
+}
+
+// remoteNode looks p up in the tree of its parent directory, it returns nil
+// when p does not exist. The repository root is always a tree.
+func (o *CopyOptions) remoteNode(p string) (*gitlab.TreeNode, error) {
+	if p == "" {
+		return &gitlab.TreeNode{Type: "tree"}, nil
+	}
+	opt := &gitlab.ListTreeOptions{
+		ListOptions: gitlab.ListOptions{
+			Pagination: "keyset",
+			PerPage:    cmdutil.DefaultChunkSize,
+		},
+		Path: pointer.ToString(cleanRepositoryPath(path.Dir(p))),
+		Ref:  pointer.ToString(o.remote.ref),
+	}
+	var found *gitlab.TreeNode
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.TreeNode, *gitlab.Response, error) {
+			return o.gitlabClient.Repositories.ListTree(o.remote.project, opt, options...)
+		}, func(items []*gitlab.TreeNode) error {
+			for _, node := range items {
+				if node.Path == p {
+					found = node
+				}
+			}
+			return nil
+		})
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return nil, nil
+	}
+	return found, err
+}
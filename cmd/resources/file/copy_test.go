@@ -0,0 +1,88 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRunCopy(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name      string
+		args      []string
+		wantError error
+	}{{
+		name:      "download file",
+		args:      []string{"Group2/SubGroup3/Project13:main:test/test.yaml", filepath.Join(dir, "test.yaml")},
+		wantError: nil,
+	}, {
+		name:      "download directory",
+		args:      []string{"Group2/SubGroup3/Project13::test", dir},
+		wantError: nil,
+	}, {
+		name:      "upload file",
+		args:      []string{"../../../testdata/push/app.yaml", "Group2/SubGroup3/Project13:main:copy/"},
+		wantError: nil,
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewCopyCmd(factory, streams)
+			var cmdOptions = NewCopyOptions(streams)
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+func TestParseRemotePath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *remotePath
+	}{
+		{in: "group/project:main:deploy/app.yaml", want: &remotePath{project: "group/project", ref: "main", path: "deploy/app.yaml"}},
+		{in: "group/project::deploy/", want: &remotePath{project: "group/project", path: "deploy", dir: true}},
+		{in: "group/project:main:", want: &remotePath{project: "group/project", ref: "main"}},
+		{in: "./a:b:c"},
+		{in: "/tmp/a:b:c"},
+		{in: "app.yaml"},
+		{in: "group/project:main"},
+	}
+	for _, tc := range tests {
+		got, ok := parseRemotePath(tc.in)
+		assert.Equal(t, tc.want != nil, ok, tc.in)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 )
 
 type DeleteOptions struct {
@@ -64,6 +65,7 @@ func NewDeleteFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Example:               deleteFilesExample,
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.FilePathCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -72,6 +74,8 @@ func NewDeleteFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		SuggestFor: []string{"file"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("branch", completion.BranchCompletionFunc(f)))
 	return cmd
 }
 func (o *DeleteOptions) AddFlags(cmd *cobra.Command) {
@@ -16,12 +16,16 @@ package file
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/diff"
 	"github.com/huhouhua/glctl/pkg/util/templates"
 
 	"github.com/AlekSi/pointer"
@@ -30,6 +34,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/util/editor"
 )
 
@@ -38,6 +43,9 @@ type EditOptions struct {
 	path         string
 	Project      string
 	file         *gitlab.GetRawFileOptions
+	mergeRequest *MergeRequestOptions
+	validate     validator
+	Validation   bool
 	ioStreams    genericiooptions.IOStreams
 }
 
@@ -47,13 +55,21 @@ func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
 		file: &gitlab.GetRawFileOptions{
 			Ref: pointer.ToString("main"),
 		},
+		mergeRequest: NewMergeRequestOptions(),
+		Validation:   true,
 	}
 }
 
 var (
 	editFileExample = templates.Examples(`
 # edit file for project
-glctl edit files myfile -p project`)
+glctl edit files myfile -p project
+
+# edit file of a protected branch through a merge request
+glctl edit files myfile -p project --ref=main --create-merge-request --assignee=reviewer
+
+# edit a file that is not valid YAML on purpose
+glctl edit files config/broken.yaml -p project --validate=false`)
 )
 
 func NewEditFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
@@ -66,6 +82,7 @@ func NewEditFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *co
 		Args:                  require.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.FilePathCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -74,6 +91,8 @@ func NewEditFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *co
 		SuggestFor: []string{"file"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
 	return cmd
 }
 
@@ -86,6 +105,13 @@ func (o *EditOptions) AddFlags(cmd *cobra.Command) {
 		*o.file.Ref,
 		"The name of a repository branch or tag or, if not given, the default branch.",
 	)
+	f.BoolVar(
+		&o.Validation,
+		"validate",
+		o.Validation,
+		"If true, validate YAML, JSON, TOML and .gitlab-ci.yml files before saving them and reopen the editor on errors.",
+	)
+	o.mergeRequest.AddFlags(cmd)
 	cmdutil.VerifyMarkFlagRequired(cmd, "project")
 }
 
@@ -95,8 +121,13 @@ func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 	if len(args) > 0 {
 		o.path = args[0]
 	}
-	o.gitlabClient, err = f.GitlabClient()
-	return err
+	if o.gitlabClient, err = f.GitlabClient(); err != nil {
+		return err
+	}
+	if o.Validation {
+		o.validate = validatorFor(o.gitlabClient, o.Project, *o.file.Ref, o.path)
+	}
+	return o.mergeRequest.Complete(o.gitlabClient)
 }
 
 // Validate makes sure there is no discrepency in command options.
@@ -105,45 +136,140 @@ func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
 		_ = cmd.Usage()
 		return fmt.Errorf("please enter project name and id")
 	}
-	return nil
+	return o.mergeRequest.Validate(cmd)
 }
 
 // Run executes a list subcommand using the specified options.
 func (o *EditOptions) Run(args []string) error {
-	raw, _, err := o.gitlabClient.RepositoryFiles.GetRawFile(o.Project, o.path, o.file)
+	file, base, err := o.getFile(*o.file.Ref)
 	if err != nil {
-		fmt.Println(err.Error())
 		return err
 	}
-	edit := editor.NewDefaultEditor([]string{"EDITOR"})
-	// generate the file to edit
-	buf := bytes.NewBuffer(raw)
-	edited, file, err := edit.LaunchTempFile(
-		fmt.Sprintf("%s-edit-", filepath.Base(os.Args[0])),
-		filepath.Ext(o.path),
-		buf,
-	)
-	defer func() {
-		// cleanup any file from the previous pass
-		if len(file) > 0 {
-			_ = os.Remove(file)
+	// the update is refused when the file changed since lastCommitID, the
+	// change of a teammate is merged in rather than overwritten.
+	lastCommitID := file.LastCommitID
+	content := base
+	branch := *o.file.Ref
+	// the file is reopened with the reason as a header while it is invalid,
+	// saving it unchanged gives up.
+	var reason string
+	var invalid bool
+	for {
+		edited, err := o.launchEditor(editor.Header(reason, invalid), content)
+		if err != nil {
+			return err
 		}
-	}()
-	if err != nil {
-		return err
+		if invalid && bytes.Equal(content, edited) {
+			return o.keep(edited, fmt.Errorf("edit cancelled, %s", reason))
+		}
+		if bytes.Equal(base, edited) {
+			_, _ = fmt.Fprintln(o.ioStreams.ErrOut, "Edit cancelled, no changes made.")
+			return nil
+		}
+		content = edited
+		if diff.HasConflictMarkers(edited) {
+			reason, invalid = fmt.Sprintf("%s still has unresolved conflicts", o.path), true
+			continue
+		}
+		if o.validate != nil {
+			if err = o.validate(edited); err != nil {
+				reason, invalid = err.Error(), true
+				continue
+			}
+		}
+		if o.mergeRequest.Create && branch == *o.file.Ref {
+			if branch, err = o.mergeRequest.prepareSourceBranch(o.gitlabClient, o.Project, branch); err != nil {
+				return o.keep(edited, err)
+			}
+		}
+		_, r, err := o.gitlabClient.RepositoryFiles.UpdateFile(o.Project, o.path, &gitlab.UpdateFileOptions{
+			Branch:        pointer.ToString(branch),
+			Content:       pointer.ToString(string(edited)),
+			CommitMessage: pointer.ToString(fmt.Sprintf("edit %s", o.path)),
+			LastCommitID:  pointer.ToString(lastCommitID),
+		})
+		if err == nil {
+			break
+		}
+		if r == nil || r.StatusCode != http.StatusBadRequest {
+			return o.keep(edited, err)
+		}
+		theirs, theirsContent, getErr := o.getFile(branch)
+		if getErr != nil || theirs.LastCommitID == lastCommitID {
+			return o.keep(edited, err)
+		}
+		merged, conflicts := diff.Merge3(base, edited, theirsContent,
+			"mine", fmt.Sprintf("%s (%s)", branch, shortID(theirs.LastCommitID)))
+		if conflicts {
+			reason = fmt.Sprintf("%s was changed on %s in the meantime, resolve the conflicts and save again", o.path, branch)
+		} else {
+			reason = fmt.Sprintf("%s was changed on %s in the meantime, review the merged changes and save again", o.path, branch)
+		}
+		base, lastCommitID, content, invalid = theirsContent, theirs.LastCommitID, merged, conflicts
 	}
-	if bytes.Equal(raw, edited) {
-		_, _ = fmt.Fprintln(o.ioStreams.ErrOut, "Edit cancelled, no changes made.")
+	_, _ = fmt.Fprintln(o.ioStreams.Out, o.path, " edited")
+	if !o.mergeRequest.Create {
 		return nil
 	}
-	_, _, err = o.gitlabClient.RepositoryFiles.UpdateFile(o.Project, o.path, &gitlab.UpdateFileOptions{
-		Branch:        o.file.Ref,
-		Content:       pointer.ToString(string(edited)),
-		CommitMessage: pointer.ToString(fmt.Sprintf("edit %s", o.path)),
+	mr, err := o.mergeRequest.open(o.gitlabClient, o.Project, *o.file.Ref, fmt.Sprintf("Edit %s", o.path))
+	if mr != nil {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request !%d: %s\n", mr.IID, mr.WebURL)
+	}
+	return err
+}
+
+// keep stores the last attempt of the edit in a temporary file so it can be
+// recovered and returns err along with where it is.
+func (o *EditOptions) keep(content []byte, err error) error {
+	file, tmpErr := os.CreateTemp("", fmt.Sprintf("%s-edit-*%s", filepath.Base(os.Args[0]), filepath.Ext(o.path)))
+	if tmpErr != nil {
+		return err
+	}
+	defer file.Close()
+	if _, tmpErr = file.Write(content); tmpErr != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.ErrOut, "A copy of your changes has been stored to %q\n", file.Name())
+	return err
+}
+
+// getFile returns the file on ref along with its decoded content.
+func (o *EditOptions) getFile(ref string) (*gitlab.File, []byte, error) {
+	file, _, err := o.gitlabClient.RepositoryFiles.GetFile(o.Project, o.path, &gitlab.GetFileOptions{
+		Ref: pointer.ToString(ref),
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	_, _ = fmt.Fprintln(o.ioStreams.Out, o.path, " edited")
-	return nil
+	if file.Encoding != "base64" {
+		return file, []byte(file.Content), nil
+	}
+	content, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode %s: %w", o.path, err)
+	}
+	return file, content, nil
+}
+
+// launchEditor opens content below header in the editor and returns the
+// edited content without the header.
+func (o *EditOptions) launchEditor(header, content []byte) ([]byte, error) {
+	edit := editor.NewDefaultEditor([]string{"EDITOR"})
+	edited, file, err := edit.LaunchTempFile(
+		fmt.Sprintf("%s-edit-", filepath.Base(os.Args[0])),
+		filepath.Ext(o.path),
+		io.MultiReader(bytes.NewReader(header), bytes.NewReader(content)),
+	)
+	// cleanup any file from the previous pass
+	if len(file) > 0 {
+		_ = os.Remove(file)
+	}
+	return editor.StripHeader(edited), err
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 )
 
 type ListOptions struct {
@@ -36,6 +37,8 @@ type ListOptions struct {
 	Out          string
 	All          bool
 	Raw          bool
+	Limit        int64
+	ChunkSize    int64
 	ioStreams    genericiooptions.IOStreams
 }
 
@@ -51,14 +54,18 @@ func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
 			Recursive: pointer.ToBool(true),
 			Ref:       pointer.ToString(""),
 		},
-		Out: "simple",
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
 	}
 }
 
 var (
 	getFilesExample = templates.Examples(`
 # list project file
-glctl get files myProject`)
+glctl get files myProject
+
+# list every file of a large repository, 500 per request
+glctl get files myProject -A --chunk-size=500`)
 )
 
 func NewGetFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
@@ -71,6 +78,7 @@ func NewGetFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *co
 		DisableFlagsInUseLine: true,
 		Args:                  require.MinimumNArgs(1),
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -79,6 +87,8 @@ func NewGetFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *co
 		SuggestFor: []string{"file"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("path", completion.FilePathCompletionFunc(f)))
 	return cmd
 }
 
@@ -86,6 +96,8 @@ func (o *ListOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddPaginationVarFlags(cmd, &o.file.ListOptions)
 	cmdutil.AddOutFlag(cmd, &o.Out)
 	cmdutil.AddSortVarFlag(cmd, &o.file.Sort)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
 	f := cmd.Flags()
 	f.StringVar(
 		o.file.Ref,
@@ -149,24 +161,23 @@ func (o *ListOptions) Run(args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(file))
-		return nil
+		_, err = o.ioStreams.Out.Write(file)
+		return err
 	}
-	var list []*gitlab.TreeNode
 	if o.All {
-		o.file.PerPage = 100
-		o.file.Page = 1
+		// the repository tree supports keyset pagination, the next page
+		// token is followed from the Link header.
+		o.file.Pagination = "keyset"
+		o.file.PerPage = o.ChunkSize
+		o.file.Page = 0
 	}
-	for {
-		tree, _, err := o.gitlabClient.Repositories.ListTree(o.project, o.file)
-		if err != nil {
-			return err
-		}
-		list = append(list, tree...)
-		if cap(tree) == 0 || !o.All {
-			break
-		}
-		o.file.Page++
+	printer := cmdutil.NewFilesPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.TreeNode, *gitlab.Response, error) {
+			return o.gitlabClient.Repositories.ListTree(o.project, o.file, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
 	}
-	return cmdutil.PrintFilesOut(o.Out, o.ioStreams.Out, list...)
+	return printer.Flush()
 }
@@ -76,6 +76,15 @@ func TestGetFiles(t *testing.T) {
 			opt.file.ListOptions.PerPage = 100
 		},
 		wantError: nil,
+	}, {
+		name: "list all file with limit and chunk size",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ListOptions) {
+			opt.All = true
+			opt.Limit = 3
+			opt.ChunkSize = 1
+		},
+		wantError: nil,
 	}, {
 		name: "desc sort",
 		args: []string{"Group2/SubGroup3/Project13"},
@@ -0,0 +1,203 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type HistoryOptions struct {
+	gitlabClient *gitlab.Client
+	commits      *gitlab.ListCommitsOptions
+	project      string
+	lines        *lineRange
+	Ref          string
+	Lines        string
+	Out          string
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+func NewHistoryOptions(ioStreams genericiooptions.IOStreams) *HistoryOptions {
+	return &HistoryOptions{
+		ioStreams: ioStreams,
+		commits: &gitlab.ListCommitsOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 20,
+			},
+		},
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+var (
+	getFileHistoryExample = templates.Examples(`
+# list the commits that changed a file on the default branch
+glctl get file-history myProject deploy/app.yaml
+
+# list every commit that changed a file on a release branch
+glctl get file-history myProject deploy/app.yaml --ref=release/1.0 -A
+
+# list the commits that last changed the lines 10 to 40 as json
+glctl get file-history myProject deploy/app.yaml -L 10,40 -o json`)
+)
+
+func NewGetFileHistoryCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewHistoryOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "file-history <project> <path>",
+		Aliases:               []string{"history"},
+		Short:                 "List the commits that changed a repository file",
+		Example:               getFileHistoryExample,
+		Args:                  require.ExactArgs(2),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.ProjectAndPathArgs(f),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+func (o *HistoryOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddPaginationVarFlags(cmd, &o.commits.ListOptions)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.StringVar(&o.Ref, "ref", o.Ref, "The name of a repository branch or tag or, if not given, the default branch.")
+	f.StringVarP(
+		&o.Lines,
+		"lines",
+		"L",
+		o.Lines,
+		"Only the commits that last changed the lines start,end, start,+count or start, of the file, e.g. -L 10,40",
+	)
+	f.BoolVarP(&o.All, "all", "A", o.All, "If present, list every commit that changed the file.")
+}
+
+// Complete completes all the required options.
+func (o *HistoryOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	if len(args) > 1 {
+		o.project = args[0]
+		o.commits.Path = pointer.ToString(args[1])
+	}
+	if o.Ref != "" {
+		o.commits.RefName = pointer.ToString(o.Ref)
+	}
+	if o.lines, err = parseLineRange(o.Lines); err != nil {
+		return err
+	}
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *HistoryOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" || o.commits.Path == nil || strings.TrimSpace(*o.commits.Path) == "" {
+		return cmdutil.UsageErrorf(cmd, "please enter the project and the path of the file")
+	}
+	return nil
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *HistoryOptions) Run(args []string) error {
+	printer := cmdutil.NewCommitsPrinter(o.Out, o.ioStreams.Out)
+	if o.lines != nil {
+		commits, err := o.lineCommits()
+		if err != nil {
+			return err
+		}
+		if err = printer.PrintChunk(commits); err != nil {
+			return err
+		}
+		return printer.Flush()
+	}
+	if o.All {
+		o.commits.PerPage = o.ChunkSize
+		o.commits.Page = 1
+	}
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Commit, *gitlab.Response, error) {
+			return o.gitlabClient.Commits.ListCommits(o.project, o.commits, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
+
+// lineCommits returns the commits blame attributes the lines of the range to,
+// newest first. The commits API can't filter by lines, so only the last
+// change of every line is known.
+func (o *HistoryOptions) lineCommits() ([]*gitlab.Commit, error) {
+	lines, err := blame(o.gitlabClient, o.project, *o.commits.Path, o.Ref, o.lines)
+	if err != nil {
+		return nil, err
+	}
+	var commits []*gitlab.Commit
+	seen := map[string]bool{}
+	for _, line := range lines {
+		c := line.Commit
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		title, _, _ := strings.Cut(c.Message, "\n")
+		commits = append(commits, &gitlab.Commit{
+			ID:             c.ID,
+			ShortID:        shortID(c.ID),
+			Title:          title,
+			Message:        c.Message,
+			ParentIDs:      c.ParentIDs,
+			AuthorName:     c.AuthorName,
+			AuthorEmail:    c.AuthorEmail,
+			AuthoredDate:   c.AuthoredDate,
+			CommitterName:  c.CommitterName,
+			CommitterEmail: c.CommitterEmail,
+			CommittedDate:  c.CommittedDate,
+		})
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		a, b := commits[i].CommittedDate, commits[j].CommittedDate
+		return a != nil && (b == nil || a.After(*b))
+	})
+	if o.Limit > 0 && int64(len(commits)) > o.Limit {
+		commits = commits[:o.Limit]
+	}
+	return commits, nil
+}
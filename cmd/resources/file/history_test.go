@@ -0,0 +1,78 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRunFileHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *HistoryOptions)
+		wantError   error
+	}{{
+		name:      "list file history",
+		args:      []string{"Group2/SubGroup3/Project13", "test/test.yaml"},
+		wantError: nil,
+	}, {
+		name: "list all file history with limit",
+		args: []string{"Group2/SubGroup3/Project13", "test/test.yaml"},
+		optionsFunc: func(opt *HistoryOptions) {
+			opt.All = true
+			opt.Limit = 5
+			opt.ChunkSize = 2
+		},
+		wantError: nil,
+	}, {
+		name: "list commits of a line range",
+		args: []string{"Group2/SubGroup3/Project13", "test/test.yaml"},
+		optionsFunc: func(opt *HistoryOptions) {
+			opt.Ref = "main"
+			opt.Lines = "1,"
+			opt.Out = "json"
+		},
+		wantError: nil,
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewGetFileHistoryCmd(factory, streams)
+			var cmdOptions = NewHistoryOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
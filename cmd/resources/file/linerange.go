@@ -0,0 +1,64 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lineRange is a range of 1-based lines of a file, like git's -L option. An
+// End of 0 means up to the end of the file.
+type lineRange struct {
+	Start int64
+	End   int64
+}
+
+// contains reports whether the 1-based line is in the range.
+func (r *lineRange) contains(line int64) bool {
+	return r == nil || line >= r.Start && (r.End == 0 || line <= r.End)
+}
+
+// parseLineRange parses "start,end", "start,+count" or "start,", an empty
+// value is the whole file.
+func parseLineRange(value string) (*lineRange, error) {
+	if value == "" {
+		return nil, nil
+	}
+	start, end, found := strings.Cut(value, ",")
+	if !found {
+		return nil, fmt.Errorf("invalid line range %q, use start,end", value)
+	}
+	r := &lineRange{}
+	var err error
+	if r.Start, err = strconv.ParseInt(start, 10, 64); err != nil || r.Start < 1 {
+		return nil, fmt.Errorf("invalid start of line range %q", value)
+	}
+	switch {
+	case end == "":
+	case strings.HasPrefix(end, "+"):
+		count, err := strconv.ParseInt(end[1:], 10, 64)
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("invalid count of line range %q", value)
+		}
+		r.End = r.Start + count - 1
+	default:
+		if r.End, err = strconv.ParseInt(end, 10, 64); err != nil || r.End < r.Start {
+			return nil, fmt.Errorf("invalid end of line range %q", value)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLineRange(t *testing.T) {
+	tests := []struct {
+		value     string
+		want      *lineRange
+		wantError string
+	}{{
+		value: "",
+	}, {
+		value: "10,40",
+		want:  &lineRange{Start: 10, End: 40},
+	}, {
+		value: "10,+5",
+		want:  &lineRange{Start: 10, End: 14},
+	}, {
+		value: "10,",
+		want:  &lineRange{Start: 10},
+	}, {
+		value:     "10",
+		wantError: `invalid line range "10", use start,end`,
+	}, {
+		value:     "0,4",
+		wantError: `invalid start of line range "0,4"`,
+	}, {
+		value:     "10,4",
+		wantError: `invalid end of line range "10,4"`,
+	}, {
+		value:     "10,+0",
+		wantError: `invalid count of line range "10,+0"`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseLineRange(tc.value)
+			if tc.wantError != "" {
+				assert.EqualError(t, err, tc.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+	r := &lineRange{Start: 10}
+	assert.True(t, r.contains(10))
+	assert.True(t, r.contains(1000))
+	assert.False(t, r.contains(9))
+	assert.True(t, (*lineRange)(nil).contains(1))
+}
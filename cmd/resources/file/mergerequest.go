@@ -0,0 +1,171 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+// MergeRequestOptions are the flags of the commands that can propose their
+// change through a merge request instead of committing to the branch. The
+// change for a target branch is committed to <prefix><target branch>.
+type MergeRequestOptions struct {
+	assigneeID         *int64
+	Create             bool
+	SourceBranchPrefix string
+	Title              string
+	Assignee           string
+	Labels             []string
+	AutoMerge          bool
+}
+
+func NewMergeRequestOptions() *MergeRequestOptions {
+	return &MergeRequestOptions{
+		SourceBranchPrefix: "glctl/",
+	}
+}
+
+func (o *MergeRequestOptions) AddFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.BoolVar(
+		&o.Create,
+		"create-merge-request",
+		o.Create,
+		"If true, commit to a new branch created from the target branch and open a merge request into it.",
+	)
+	f.StringVar(
+		&o.SourceBranchPrefix,
+		"source-branch-prefix",
+		o.SourceBranchPrefix,
+		"The prefix of the merge request branch, followed by the name of the target branch.",
+	)
+	f.StringVar(&o.Title, "title", o.Title, "The title of the merge request.")
+	f.StringVar(&o.Assignee, "assignee", o.Assignee, "The username of the user the merge request is assigned to.")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Comma-separated label names of the merge request.")
+	f.BoolVar(&o.AutoMerge, "auto-merge", o.AutoMerge, "If true, merge the merge request when its pipeline succeeds.")
+}
+
+// Complete resolves the assignee of the merge requests.
+func (o *MergeRequestOptions) Complete(client *gitlab.Client) error {
+	if !o.Create || o.Assignee == "" {
+		return nil
+	}
+	users, _, err := client.Users.ListUsers(&gitlab.ListUsersOptions{Username: pointer.ToString(o.Assignee)})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return fmt.Errorf("user %s not found", o.Assignee)
+	}
+	o.assigneeID = pointer.To(users[0].ID)
+	return nil
+}
+
+// Validate makes sure the merge request flags are only used with --create-merge-request.
+func (o *MergeRequestOptions) Validate(cmd *cobra.Command) error {
+	if o.Create {
+		if o.SourceBranchPrefix == "" {
+			return cmdutil.UsageErrorf(cmd, "--source-branch-prefix can not be empty")
+		}
+		return nil
+	}
+	for _, name := range []string{"source-branch-prefix", "title", "assignee", "labels", "auto-merge"} {
+		if cmd.Flags().Changed(name) {
+			return cmdutil.UsageErrorf(cmd, "--%s requires --create-merge-request", name)
+		}
+	}
+	return nil
+}
+
+// SourceBranch returns the branch the change for target is committed to.
+func (o *MergeRequestOptions) SourceBranch(target string) string {
+	return o.SourceBranchPrefix + target
+}
+
+// prepareSourceBranch creates the source branch of target from target, an
+// existing source branch is reused so that reruns add to the same change.
+func (o *MergeRequestOptions) prepareSourceBranch(client *gitlab.Client, pid interface{}, target string) (string, error) {
+	source := o.SourceBranch(target)
+	_, _, err := client.Branches.GetBranch(pid, source)
+	if err == nil {
+		return source, nil
+	}
+	if !errors.Is(err, gitlab.ErrNotFound) {
+		return "", err
+	}
+	_, _, err = client.Branches.CreateBranch(pid, &gitlab.CreateBranchOptions{
+		Branch: pointer.ToString(source),
+		Ref:    pointer.ToString(target),
+	})
+	return source, err
+}
+
+// open opens a merge request from the source branch of target into target,
+// an open merge request between the two branches is reused.
+func (o *MergeRequestOptions) open(
+	client *gitlab.Client,
+	pid interface{},
+	target, title string,
+) (*gitlab.BasicMergeRequest, error) {
+	source := o.SourceBranch(target)
+	opened, _, err := client.MergeRequests.ListProjectMergeRequests(pid, &gitlab.ListProjectMergeRequestsOptions{
+		State:        pointer.ToString("opened"),
+		SourceBranch: pointer.ToString(source),
+		TargetBranch: pointer.ToString(target),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var mr *gitlab.BasicMergeRequest
+	if len(opened) > 0 {
+		mr = opened[0]
+	} else {
+		if o.Title != "" {
+			title = o.Title
+		}
+		opt := &gitlab.CreateMergeRequestOptions{
+			Title:              pointer.ToString(title),
+			SourceBranch:       pointer.ToString(source),
+			TargetBranch:       pointer.ToString(target),
+			AssigneeID:         o.assigneeID,
+			RemoveSourceBranch: pointer.ToBool(true),
+		}
+		if len(o.Labels) > 0 {
+			opt.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+		}
+		created, _, err := client.MergeRequests.CreateMergeRequest(pid, opt)
+		if err != nil {
+			return nil, err
+		}
+		mr = &created.BasicMergeRequest
+	}
+	if !o.AutoMerge {
+		return mr, nil
+	}
+	_, _, err = client.MergeRequests.AcceptMergeRequest(pid, mr.IID, &gitlab.AcceptMergeRequestOptions{
+		AutoMerge: pointer.ToBool(true),
+	})
+	if err != nil {
+		return mr, fmt.Errorf("auto-merge of %s could not be enabled: %w", mr.WebURL, err)
+	}
+	return mr, nil
+}
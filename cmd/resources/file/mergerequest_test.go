@@ -0,0 +1,60 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestMergeRequestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantError error
+	}{{
+		name: "merge request flags",
+		args: []string{"--create-merge-request", "--title=update ci", "--labels=ci,bot", "--auto-merge"},
+	}, {
+		name: "title without create merge request",
+		args: []string{"--title=update ci"},
+		wantError: errors.New("--title requires --create-merge-request\n" +
+			"See 'file -h' for help and examples"),
+	}, {
+		name: "empty source branch prefix",
+		args: []string{"--create-merge-request", "--source-branch-prefix="},
+		wantError: errors.New("--source-branch-prefix can not be empty\n" +
+			"See 'file -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "file"}
+			o := NewMergeRequestOptions()
+			o.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.args); err != nil {
+				t.Fatal(err)
+			}
+			err := o.Validate(cmd)
+			if tc.wantError == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+const executableFileMode = "100755"
+
+type PushOptions struct {
+	gitlabClient  *gitlab.Client
+	dir           string
+	Project       string
+	Branch        string
+	Message       string
+	Prefix        string
+	DeleteMissing bool
+	DryRun        bool
+	ioStreams     genericiooptions.IOStreams
+}
+
+func NewPushOptions(ioStreams genericiooptions.IOStreams) *PushOptions {
+	return &PushOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+var (
+	pushFilesExample = templates.Examples(`
+# push the files of ./deploy to the deploy directory of the main branch
+glctl push files ./deploy -p myproject --branch=main --prefix=deploy/ -m "update deploy manifests"
+
+# make the deploy directory mirror ./deploy, removing files that only exist remotely
+glctl push files ./deploy -p myproject --branch=main --prefix=deploy/ --delete-missing
+
+# show the actions the commit would contain without pushing anything
+glctl push files ./deploy -p myproject --branch=main --prefix=deploy/ --delete-missing --dry-run`)
+)
+
+func NewPushFilesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewPushOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "files",
+		Aliases:               []string{"f", "file"},
+		Short:                 "push files of a local directory to a branch as one commit",
+		Example:               pushFilesExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("branch", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("prefix", completion.FilePathCompletionFunc(f)))
+	return cmd
+}
+
+func (o *PushOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.Project)
+	f := cmd.Flags()
+	f.StringVar(&o.Branch, "branch", o.Branch, "The name of the branch to commit to or, if not given, the default branch.")
+	f.StringVarP(&o.Message, "message", "m", o.Message, "The commit message.")
+	f.StringVar(&o.Prefix, "prefix", o.Prefix, "The repository directory the local directory is pushed to.")
+	f.BoolVar(
+		&o.DeleteMissing,
+		"delete-missing",
+		o.DeleteMissing,
+		"If true, delete repository files under --prefix that do not exist in the local directory.",
+	)
+	f.BoolVar(&o.DryRun, "dry-run", o.DryRun, "If true, only print the actions of the commit without pushing it.")
+	cmdutil.VerifyMarkFlagRequired(cmd, "project")
+}
+
+// Complete completes all the required options.
+func (o *PushOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.dir = args[0]
+	}
+	o.Prefix = cleanRepositoryPath(o.Prefix)
+	if strings.TrimSpace(o.Message) == "" {
+		o.Message = fmt.Sprintf("push %s from glctl command line", filepath.Base(filepath.Clean(o.dir)))
+	}
+	gitlabClient, err := f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.gitlabClient = gitlabClient
+	if strings.TrimSpace(o.Branch) != "" {
+		return nil
+	}
+	project, _, err := o.gitlabClient.Projects.GetProject(o.Project, &gitlab.GetProjectOptions{})
+	if err != nil {
+		return err
+	}
+	o.Branch = project.DefaultBranch
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *PushOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.Project) == "" {
+		_ = cmd.Usage()
+		return fmt.Errorf("please enter project name and id")
+	}
+	fi, err := os.Stat(o.dir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the path %s does not exist", o.dir)
+	}
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("the path %s is not a dir", o.dir)
+	}
+	return nil
+}
+
+// Run executes a push subcommand using the specified options.
+func (o *PushOptions) Run(args []string) error {
+	local, err := readLocalTree(o.dir)
+	if err != nil {
+		return err
+	}
+	remote, err := listRemoteTree(o.gitlabClient, o.Project, o.Branch, o.Prefix)
+	if err != nil {
+		return err
+	}
+	actions := commitActions(local, remote, o.Prefix, o.DeleteMissing)
+	if len(actions) == 0 {
+		_, err = fmt.Fprintf(o.ioStreams.Out, "branch %s is up to date, nothing to push\n", o.Branch)
+		return err
+	}
+	if err = printCommitActions(o.ioStreams, actions); err != nil {
+		return err
+	}
+	if o.DryRun {
+		return nil
+	}
+	commit, _, err := o.gitlabClient.Commits.CreateCommit(o.Project, &gitlab.CreateCommitOptions{
+		Branch:        pointer.ToString(o.Branch),
+		CommitMessage: pointer.ToString(o.Message),
+		Actions:       actions,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.ioStreams.Out, "commit %s pushed to branch %s with %d actions\n",
+		commit.ShortID, o.Branch, len(actions))
+	return err
+}
+
+// localFile is a regular file read from the local tree, path is relative
+// to the root of the tree and always slash separated.
+type localFile struct {
+	path       string
+	content    []byte
+	executable bool
+}
+
+// readLocalTree reads every regular file below dir, .git directories are skipped.
+func readLocalTree(dir string) (map[string]*localFile, error) {
+	files := map[string]*localFile{}
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		file, err := readLocalFile(name, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		files[file.path] = file
+		return nil
+	})
+	return files, err
+}
+
+// readLocalFile reads the file name into a localFile stored at rel.
+func readLocalFile(name, rel string) (*localFile, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{
+		path:       rel,
+		content:    content,
+		executable: info.Mode().Perm()&0o111 != 0,
+	}, nil
+}
+
+// listRemoteTree lists the blobs below prefix on ref keyed by their path
+// relative to prefix. A prefix that does not exist yet yields an empty tree.
+func listRemoteTree(client *gitlab.Client, project, ref, prefix string) (map[string]*gitlab.TreeNode, error) {
+	nodes := map[string]*gitlab.TreeNode{}
+	opt := &gitlab.ListTreeOptions{
+		ListOptions: gitlab.ListOptions{
+			Pagination: "keyset",
+			PerPage:    cmdutil.DefaultChunkSize,
+		},
+		Path:      pointer.ToString(prefix),
+		Ref:       pointer.ToString(ref),
+		Recursive: pointer.ToBool(true),
+	}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.TreeNode, *gitlab.Response, error) {
+			return client.Repositories.ListTree(project, opt, options...)
+		}, func(items []*gitlab.TreeNode) error {
+			for _, node := range items {
+				if node.Type != "blob" {
+					continue
+				}
+				nodes[relativeRepositoryPath(prefix, node.Path)] = node
+			}
+			return nil
+		})
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return nodes, nil
+	}
+	return nodes, err
+}
+
+// commitActions compares the local tree with the remote tree below prefix
+// and returns the actions of a commit that brings the remote tree in line.
+// Remote files missing locally are only deleted when deleteMissing is set,
+// a deleted file whose content reappears under a new path becomes a move.
+func commitActions(
+	local map[string]*localFile,
+	remote map[string]*gitlab.TreeNode,
+	prefix string,
+	deleteMissing bool,
+) []*gitlab.CommitActionOptions {
+	var actions []*gitlab.CommitActionOptions
+	var created []*localFile
+	for _, file := range local {
+		node, ok := remote[file.path]
+		if !ok {
+			created = append(created, file)
+			continue
+		}
+		executable := node.Mode == executableFileMode
+		switch {
+		case node.ID != blobSHA(file.content):
+			actions = append(actions, contentAction(gitlab.FileUpdate, prefix, file))
+		case executable != file.executable:
+			actions = append(actions, chmodAction(prefix, file))
+		}
+	}
+	// deleted holds the remote files missing locally by path, and moves the
+	// ones of them that are still left to be moved by their content.
+	deleted := map[string]bool{}
+	moves := map[string][]string{}
+	if deleteMissing {
+		for name, node := range remote {
+			if _, ok := local[name]; !ok {
+				deleted[name] = true
+				moves[node.ID] = append(moves[node.ID], name)
+			}
+		}
+		for _, names := range moves {
+			sort.Strings(names)
+		}
+	}
+	sort.Slice(created, func(i, j int) bool {
+		return created[i].path < created[j].path
+	})
+	for _, file := range created {
+		sha := blobSHA(file.content)
+		names := moves[sha]
+		if len(names) == 0 {
+			actions = append(actions, contentAction(gitlab.FileCreate, prefix, file))
+			continue
+		}
+		previous := names[0]
+		moves[sha] = names[1:]
+		delete(deleted, previous)
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:       pointer.To(gitlab.FileMove),
+			FilePath:     pointer.ToString(path.Join(prefix, file.path)),
+			PreviousPath: pointer.ToString(path.Join(prefix, previous)),
+		})
+		// a move keeps the mode of the previous file.
+		if (remote[previous].Mode == executableFileMode) != file.executable {
+			actions = append(actions, chmodAction(prefix, file))
+		}
+	}
+	for name := range deleted {
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:   pointer.To(gitlab.FileDelete),
+			FilePath: pointer.ToString(path.Join(prefix, name)),
+		})
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		return *actions[i].FilePath < *actions[j].FilePath
+	})
+	return actions
+}
+
+func chmodAction(prefix string, file *localFile) *gitlab.CommitActionOptions {
+	return &gitlab.CommitActionOptions{
+		Action:          pointer.To(gitlab.FileChmod),
+		FilePath:        pointer.ToString(path.Join(prefix, file.path)),
+		ExecuteFilemode: pointer.ToBool(file.executable),
+	}
+}
+
+func contentAction(action gitlab.FileActionValue, prefix string, file *localFile) *gitlab.CommitActionOptions {
+	content, encoding := encodeContent(file.content)
+	return &gitlab.CommitActionOptions{
+		Action:          pointer.To(action),
+		FilePath:        pointer.ToString(path.Join(prefix, file.path)),
+		Content:         pointer.ToString(content),
+		Encoding:        pointer.ToString(encoding),
+		ExecuteFilemode: pointer.ToBool(file.executable),
+	}
+}
+
+func printCommitActions(ioStreams genericiooptions.IOStreams, actions []*gitlab.CommitActionOptions) error {
+	printer := cmdutil.NewListPrinter("simple", ioStreams.Out, []string{"Action", "Path"},
+		func(action *gitlab.CommitActionOptions) []string {
+			name := *action.FilePath
+			if action.PreviousPath != nil {
+				name = fmt.Sprintf("%s -> %s", *action.PreviousPath, name)
+			}
+			return []string{string(*action.Action), name}
+		})
+	if err := printer.PrintChunk(actions); err != nil {
+		return err
+	}
+	return printer.Flush()
+}
+
+// encodeContent returns content as text when it is valid UTF-8 and base64
+// encoded otherwise, along with the encoding GitLab expects for it.
+func encodeContent(content []byte) (string, string) {
+	if utf8.Valid(content) && bytes.IndexByte(content, 0) < 0 {
+		return string(content), "text"
+	}
+	return base64.StdEncoding.EncodeToString(content), "base64"
+}
+
+// blobSHA returns the git object id of content, the same id the repository
+// tree reports for a blob.
+func blobSHA(content []byte) string {
+	h := sha1.New()
+	_, _ = fmt.Fprintf(h, "blob %d\x00", len(content))
+	_, _ = h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cleanRepositoryPath normalizes a repository directory, the root is "".
+func cleanRepositoryPath(p string) string {
+	p = strings.Trim(path.Clean("/"+strings.TrimSpace(p)), "/")
+	if p == "." {
+		return ""
+	}
+	return p
+}
+
+func relativeRepositoryPath(prefix, p string) string {
+	if prefix == "" {
+		return p
+	}
+	return strings.TrimPrefix(p, prefix+"/")
+}
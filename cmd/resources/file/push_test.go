@@ -0,0 +1,166 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRunPush(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *PushOptions)
+		wantError   error
+	}{{
+		name: "dry run push directory",
+		args: []string{"../../../testdata/push"},
+		optionsFunc: func(opt *PushOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.Branch = "main"
+			opt.Prefix = "push/"
+			opt.DeleteMissing = true
+			opt.DryRun = true
+		},
+		wantError: nil,
+	}, {
+		name: "push directory",
+		args: []string{"../../../testdata/push"},
+		optionsFunc: func(opt *PushOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.Branch = "main"
+			opt.Prefix = "push/"
+			opt.Message = "push testdata from glctl test"
+		},
+		wantError: nil,
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewPushFilesCmd(factory, streams)
+			var cmdOptions = NewPushOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+func TestCommitActions(t *testing.T) {
+	local := map[string]*localFile{
+		"same.yaml":    {path: "same.yaml", content: []byte("same\n")},
+		"changed.yaml": {path: "changed.yaml", content: []byte("new\n")},
+		"new.yaml":     {path: "new.yaml", content: []byte("new file\n")},
+		"renamed.yaml": {path: "renamed.yaml", content: []byte("moved\n")},
+		"run.sh":       {path: "run.sh", content: []byte("#!/bin/sh\n"), executable: true},
+		"logo.png":     {path: "logo.png", content: []byte{0x89, 'P', 'N', 'G', 0x00}},
+		"copy.yaml":    {path: "copy.yaml", content: []byte("copy\n")},
+		"deploy.sh":    {path: "deploy.sh", content: []byte("deploy\n"), executable: true},
+	}
+	remote := map[string]*gitlab.TreeNode{
+		"same.yaml":    {ID: blobSHA([]byte("same\n")), Mode: "100644"},
+		"changed.yaml": {ID: blobSHA([]byte("old\n")), Mode: "100644"},
+		"moved.yaml":   {ID: blobSHA([]byte("moved\n")), Mode: "100644"},
+		"run.sh":       {ID: blobSHA([]byte("#!/bin/sh\n")), Mode: "100644"},
+		"stale.yaml":   {ID: blobSHA([]byte("stale\n")), Mode: "100644"},
+		"copy-a.yaml":  {ID: blobSHA([]byte("copy\n")), Mode: "100644"},
+		"copy-b.yaml":  {ID: blobSHA([]byte("copy\n")), Mode: "100644"},
+		"dup-1.yaml":   {ID: blobSHA([]byte("dup\n")), Mode: "100644"},
+		"dup-2.yaml":   {ID: blobSHA([]byte("dup\n")), Mode: "100644"},
+		"deploy.txt":   {ID: blobSHA([]byte("deploy\n")), Mode: "100644"},
+	}
+	tests := []struct {
+		name          string
+		deleteMissing bool
+		want          []string
+	}{{
+		name: "keep missing files",
+		want: []string{
+			"update deploy/changed.yaml",
+			"create deploy/copy.yaml",
+			"create deploy/deploy.sh",
+			"create deploy/logo.png",
+			"create deploy/new.yaml",
+			"create deploy/renamed.yaml",
+			"chmod deploy/run.sh",
+		},
+	}, {
+		name:          "delete missing files",
+		deleteMissing: true,
+		want: []string{
+			"update deploy/changed.yaml",
+			"delete deploy/copy-b.yaml",
+			"move deploy/copy-a.yaml deploy/copy.yaml",
+			"move deploy/deploy.txt deploy/deploy.sh",
+			"chmod deploy/deploy.sh",
+			"delete deploy/dup-1.yaml",
+			"delete deploy/dup-2.yaml",
+			"create deploy/logo.png",
+			"create deploy/new.yaml",
+			"move deploy/moved.yaml deploy/renamed.yaml",
+			"chmod deploy/run.sh",
+			"delete deploy/stale.yaml",
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, action := range commitActions(local, remote, "deploy", tc.deleteMissing) {
+				s := string(*action.Action)
+				if action.PreviousPath != nil {
+					s += " " + *action.PreviousPath
+				}
+				got = append(got, s+" "+*action.FilePath)
+				if *action.FilePath == "deploy/logo.png" {
+					assert.Equal(t, "base64", *action.Encoding)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCleanRepositoryPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"":          "",
+		"/":         "",
+		".":         "",
+		"deploy/":   "deploy",
+		"/a/b/../c": "a/c",
+	} {
+		assert.Equal(t, want, cleanRepositoryPath(in), in)
+	}
+}
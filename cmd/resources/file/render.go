@@ -0,0 +1,70 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"gopkg.in/yaml.v3"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+// templateData is what a file template is rendered with for every target
+// branch, for example {{ .Branch }} or {{ .Values.image.tag }}.
+type templateData struct {
+	Branch  string
+	Project *gitlab.Project
+	Commit  *gitlab.Commit
+	Values  map[string]interface{}
+}
+
+// parseTemplate parses content as the template of the file name, a missing
+// key is an error rather than an empty string.
+func parseTemplate(name string, content []byte) (*template.Template, error) {
+	tmpl, err := template.New(filepath.Base(name)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// readValues reads the YAML values file name, an empty name has no values.
+func readValues(name string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if name == "" {
+		return values, nil
+	}
+	content, err := cmdutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("parse values %s: %w", name, err)
+	}
+	return values, nil
+}
+
+func renderTemplate(tmpl *template.Template, data *templateData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	values, err := readValues("../../../testdata/replace/values.yaml")
+	assert.NoError(t, err)
+	tests := []struct {
+		name      string
+		content   string
+		want      string
+		wantError string
+	}{{
+		name:    "render branch, project, commit and values",
+		content: "{{ .Branch }} {{ .Project.PathWithNamespace }} {{ .Commit.ShortID }} {{ .Values.image.tag }}",
+		want:    "release/v1 group/project 1a2b3c v1.2.0",
+	}, {
+		name:      "missing value",
+		content:   "{{ .Values.missing }}",
+		wantError: `map has no entry for key "missing"`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := parseTemplate("my.yml", []byte(tc.content))
+			assert.NoError(t, err)
+			got, err := renderTemplate(tmpl, &templateData{
+				Branch:  "release/v1",
+				Project: &gitlab.Project{PathWithNamespace: "group/project"},
+				Commit:  &gitlab.Commit{ShortID: "1a2b3c"},
+				Values:  values,
+			})
+			if tc.wantError != "" {
+				assert.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
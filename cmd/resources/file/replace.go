@@ -15,13 +15,17 @@
 package file
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"sync"
+	"text/template"
 
 	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/diff"
 	"github.com/huhouhua/glctl/pkg/util/progress"
 	"github.com/huhouhua/glctl/pkg/util/templates"
 
@@ -32,19 +36,34 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 )
 
 type ReplaceOptions struct {
-	gitlabClient *gitlab.Client
-	path         string
-	branchList   *gitlab.ListBranchesOptions
-	content      []byte
-	Project      string
-	Ref          string
-	RefMatch     string
-	FileName     string
-	Force        bool
-	ioStreams    genericiooptions.IOStreams
+	gitlabClient     *gitlab.Client
+	path             string
+	branchList       *gitlab.ListBranchesOptions
+	content          []byte
+	tmpl             *template.Template
+	values           map[string]interface{}
+	projectRegex     *regexp.Regexp
+	mergeRequest     *MergeRequestOptions
+	Project          string
+	Group            string
+	IncludeSubgroups bool
+	Topic            string
+	Search           string
+	ProjectRegex     string
+	Ref              string
+	RefMatch         string
+	FileName         string
+	Template         bool
+	Values           string
+	Force            bool
+	DryRun           bool
+	DiffOnly         bool
+	Parallelism      int
+	ioStreams        genericiooptions.IOStreams
 }
 
 func NewReplaceOptions(ioStreams genericiooptions.IOStreams) *ReplaceOptions {
@@ -56,13 +75,33 @@ func NewReplaceOptions(ioStreams genericiooptions.IOStreams) *ReplaceOptions {
 				PerPage: 10,
 			},
 		},
+		Parallelism:  10,
+		mergeRequest: NewMergeRequestOptions(),
 	}
 }
 
 var (
 	replaceFileExample = templates.Examples(`
 # edit file for project
-glctl replace files app/my.yml -p myproject --ref=main -f ./my.yml`)
+glctl replace files app/my.yml -p myproject --ref=main -f ./my.yml
+
+# render my.yml for every release branch, e.g. "env: {{ .Branch }}" or "tag: {{ .Values.image.tag }}"
+glctl replace files app/my.yml -p myproject --ref-match=^release/ -f ./my.yml --values=./values.yaml
+
+# replace .gitlab-ci.yml on the main branch of every project in a group and its subgroups
+glctl replace files .gitlab-ci.yml --group=mygroup --include-subgroups --ref=main -f ./.gitlab-ci.yml
+
+# replace the file in the projects of a topic whose path matches a regex, 4 branches at a time
+glctl replace files app/my.yml --topic=backend --project-regex=^mygroup/svc- --ref=main -f ./my.yml --parallelism=4
+
+# propose the change to the protected main branches through merge requests from glctl/main
+glctl replace files .gitlab-ci.yml --group=mygroup --ref=main -f ./.gitlab-ci.yml --create-merge-request --labels=ci --auto-merge
+
+# preview the change on every branch as a colored diff, nothing is committed
+glctl replace files app/my.yml -p myproject --ref-match=^release/ -f ./my.yml --dry-run
+
+# print only the diffs, e.g. to review them in a pager
+glctl replace files .gitlab-ci.yml --group=mygroup --ref=main -f ./.gitlab-ci.yml --diff-only | less -R`)
 )
 
 func NewReplaceFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
@@ -75,6 +114,7 @@ func NewReplaceFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Args:                  require.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.FilePathCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -83,11 +123,24 @@ func NewReplaceFileCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		SuggestFor: []string{"file"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("group", completion.GroupCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
 	return cmd
 }
 func (o *ReplaceOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddProjectVarPFlag(cmd, &o.Project)
 	f := cmd.Flags()
+	f.StringVar(&o.Group, "group", o.Group, "Replace the file in the projects of this group instead of a single --project.")
+	f.BoolVar(&o.IncludeSubgroups, "include-subgroups", o.IncludeSubgroups, "Include the projects of the subgroups of --group.")
+	f.StringVar(&o.Topic, "topic", o.Topic, "Replace the file in the projects with this topic.")
+	f.StringVar(&o.Search, "search", o.Search, "Replace the file in the projects matching this search criteria.")
+	f.StringVar(
+		&o.ProjectRegex,
+		"project-regex",
+		o.ProjectRegex,
+		"Only replace the file in the selected projects whose full path matches this regular expression.",
+	)
 	f.StringVar(&o.Ref, "ref", o.Ref, "The name of a repository branch or tag or, if not given, the default branch.")
 	f.StringVar(
 		&o.RefMatch,
@@ -96,13 +149,28 @@ func (o *ReplaceOptions) AddFlags(cmd *cobra.Command) {
 		"match repository branch or tag or, if not given, the use --ref matching branch.",
 	)
 	f.StringVarP(&o.FileName, "filename", "f", "", "to use to replace the repository file .")
+	f.BoolVar(
+		&o.Template,
+		"template",
+		o.Template,
+		"If true, render the file as a Go template for every branch with .Branch, .Project, .Commit and .Values.",
+	)
+	f.StringVar(&o.Values, "values", o.Values, "A YAML file with the .Values of the template, implies --template.")
 	f.BoolVar(
 		&o.Force,
 		"force",
 		o.Force,
 		"If true, immediately remove repository file from API and bypass graceful deletion. Note that immediate deletion of some  repository file may result in inconsistency or data loss and requires confirmation.",
 	)
-	cmdutil.VerifyMarkFlagRequired(cmd, "project")
+	f.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"If true, only print the diff of every branch and the resulting matrix, nothing is committed.",
+	)
+	f.BoolVar(&o.DiffOnly, "diff-only", o.DiffOnly, "If true, only print the diffs without the matrix, implies --dry-run.")
+	f.IntVar(&o.Parallelism, "parallelism", o.Parallelism, "The number of branches replaced at the same time.")
+	o.mergeRequest.AddFlags(cmd)
 	cmdutil.VerifyMarkFlagRequired(cmd, "filename")
 }
 
@@ -117,93 +185,445 @@ func (o *ReplaceOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []
 	if strings.TrimSpace(o.RefMatch) != "" {
 		o.branchList.Regex = pointer.ToString(o.RefMatch)
 	}
+	var err error
+	if o.ProjectRegex != "" {
+		if o.projectRegex, err = regexp.Compile(o.ProjectRegex); err != nil {
+			return fmt.Errorf("invalid --project-regex: %w", err)
+		}
+	}
 	gitlabClient, err := f.GitlabClient()
 	if err != nil {
 		return err
 	}
 	o.gitlabClient = gitlabClient
-	o.content, err = cmdutil.ReadFile(o.FileName)
+	if err = o.mergeRequest.Complete(gitlabClient); err != nil {
+		return err
+	}
+	if o.content, err = cmdutil.ReadFile(o.FileName); err != nil {
+		return err
+	}
+	if o.Values != "" {
+		o.Template = true
+	}
+	if o.DiffOnly {
+		o.DryRun = true
+	}
+	if !o.Template {
+		return nil
+	}
+	if o.tmpl, err = parseTemplate(o.FileName, o.content); err != nil {
+		return err
+	}
+	o.values, err = readValues(o.Values)
 	return err
 }
 
 // Validate makes sure there is no discrepency in command options.
 func (o *ReplaceOptions) Validate(cmd *cobra.Command, args []string) error {
-	if strings.TrimSpace(o.Project) == "" {
+	selected := o.Group != "" || o.Topic != "" || o.Search != "" || o.ProjectRegex != ""
+	if strings.TrimSpace(o.Project) == "" && !selected {
 		_ = cmd.Usage()
 		return fmt.Errorf("please enter project name and id")
 	}
-	return nil
+	if o.Project != "" && selected {
+		return cmdutil.UsageErrorf(cmd, "--project can not be combined with --group, --topic, --search or --project-regex")
+	}
+	if o.IncludeSubgroups && o.Group == "" {
+		return cmdutil.UsageErrorf(cmd, "--include-subgroups requires --group")
+	}
+	if o.Parallelism < 1 {
+		return cmdutil.UsageErrorf(cmd, "--parallelism must be at least 1")
+	}
+	return o.mergeRequest.Validate(cmd)
+}
+
+const (
+	replaceUpdated   = "updated"
+	replaceCreated   = "created"
+	replaceUnchanged = "unchanged"
+	replaceFailed    = "failed"
+
+	replaceWouldUpdate = "would update"
+	replaceWouldCreate = "would create"
+)
+
+// replaceTarget is a branch of a selected project the file is replaced in.
+type replaceTarget struct {
+	project *gitlab.Project
+	branch  *gitlab.Branch
+}
+
+type replaceResult struct {
+	replaceTarget
+	status       string
+	mergeRequest *gitlab.BasicMergeRequest
+	diff         string
+	err          error
 }
 
 // Run executes a list subcommand using the specified options.
 func (o *ReplaceOptions) Run(args []string) error {
-	for {
-		branches, err := o.next()
+	projects, err := o.projects()
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no project matches the given selectors")
+	}
+	var targets []replaceTarget
+	for _, project := range projects {
+		branches, err := o.branches(project)
 		if err != nil {
 			return err
 		}
-		if len(branches) == 0 {
-			break
+		for _, branch := range branches {
+			targets = append(targets, replaceTarget{project: project, branch: branch})
 		}
-		var wg = sync.WaitGroup{}
-		for _, item := range branches {
-			wg.Add(1)
-			go func(branch *gitlab.Branch) {
-				defer wg.Done()
-				o.update(branch)
-			}(item)
-		}
-		wg.Wait()
 	}
-	return nil
+	results := make([]*replaceResult, len(targets))
+	var wg = sync.WaitGroup{}
+	limit := make(chan struct{}, o.Parallelism)
+	for i, target := range targets {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, target replaceTarget) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			results[i] = o.update(target)
+		}(i, target)
+	}
+	wg.Wait()
+	return o.printResults(results)
 }
 
-func (o *ReplaceOptions) update(branch *gitlab.Branch) {
-	s := progress.CreatingEvent(false).WithText(fmt.Sprintf(" %s ...", branch.Name)).Start()
+func (o *ReplaceOptions) update(target replaceTarget) *replaceResult {
+	project, branch := target.project, target.branch
+	result := &replaceResult{replaceTarget: target, status: replaceFailed}
+	s := progress.CreatingEvent(false).
+		WithText(fmt.Sprintf(" %s %s ...", project.PathWithNamespace, branch.Name)).
+		Start()
 	defer s.Stop()
-	_, r, err := o.gitlabClient.RepositoryFiles.UpdateFile(o.Project, o.path, &gitlab.UpdateFileOptions{
-		Branch:        pointer.ToString(branch.Name),
+	fail := func(err error) *replaceResult {
+		result.err = err
+		s.Error("Error\n", err.Error())
+		return result
+	}
+	content, err := o.render(target)
+	if err != nil {
+		return fail(err)
+	}
+	if o.DryRun {
+		if err = o.preview(result, content); err != nil {
+			return fail(err)
+		}
+		s.Success(color.YellowString(result.status))
+		return result
+	}
+	if o.unchanged(project.ID, branch.Name, content) {
+		result.status = replaceUnchanged
+		s.Success(color.YellowString("unchanged, skipped"))
+		return result
+	}
+	if !o.mergeRequest.Create {
+		var message string
+		if result.status, message, err = o.commit(project.ID, branch.Name, content); err != nil {
+			return fail(err)
+		}
+		if result.status == replaceCreated {
+			s.Warning(" ", message, color.GreenString(" try create new a successfully"))
+			return result
+		}
+		s.Success()
+		return result
+	}
+	source, err := o.mergeRequest.prepareSourceBranch(o.gitlabClient, project.ID, branch.Name)
+	if err != nil {
+		return fail(err)
+	}
+	// a rerun finds the change already committed to the source branch.
+	result.status = replaceUnchanged
+	if !o.unchanged(project.ID, source, content) {
+		if result.status, _, err = o.commit(project.ID, source, content); err != nil {
+			return fail(err)
+		}
+	}
+	result.mergeRequest, err = o.mergeRequest.open(o.gitlabClient, project.ID, branch.Name,
+		fmt.Sprintf("Update %s", o.path))
+	if result.mergeRequest == nil {
+		return fail(err)
+	}
+	if err != nil {
+		result.err = err
+		s.Warning(result.mergeRequest.WebURL, err.Error())
+		return result
+	}
+	s.Success(result.mergeRequest.WebURL)
+	return result
+}
+
+// preview sets the status the branch would get and the diff of the change
+// into result without committing anything. A merge request would merge into
+// the branch, so the diff is against the branch in both modes.
+func (o *ReplaceOptions) preview(result *replaceResult, content []byte) error {
+	name := fmt.Sprintf("%s:%s:%s", result.project.PathWithNamespace, result.branch.Name, o.path)
+	from := "a/" + name
+	current, _, err := o.gitlabClient.RepositoryFiles.GetRawFile(result.project.ID, o.path, &gitlab.GetRawFileOptions{
+		Ref: pointer.ToString(result.branch.Name),
+	})
+	switch {
+	case errors.Is(err, gitlab.ErrNotFound):
+		if !o.Force {
+			return fmt.Errorf("%s does not exist, use --force to create it", o.path)
+		}
+		result.status, from = replaceWouldCreate, "/dev/null"
+	case err != nil:
+		return err
+	case bytes.Equal(current, content):
+		result.status = replaceUnchanged
+		return nil
+	default:
+		result.status = replaceWouldUpdate
+	}
+	result.diff, err = diff.Unified(current, content, from, "b/"+name)
+	return err
+}
+
+// unchanged reports whether the file on branch already has content.
+func (o *ReplaceOptions) unchanged(pid interface{}, branch string, content []byte) bool {
+	current, _, err := o.gitlabClient.RepositoryFiles.GetRawFile(pid, o.path, &gitlab.GetRawFileOptions{
+		Ref: pointer.ToString(branch),
+	})
+	return err == nil && bytes.Equal(current, content)
+}
+
+// commit updates the file on branch, with --force a missing file is created
+// and the reason the update failed is returned along.
+func (o *ReplaceOptions) commit(pid interface{}, branch string, content []byte) (string, string, error) {
+	_, r, err := o.gitlabClient.RepositoryFiles.UpdateFile(pid, o.path, &gitlab.UpdateFileOptions{
+		Branch:        pointer.ToString(branch),
 		CommitMessage: pointer.ToString(fmt.Sprintf("update %s from glctl command line", o.path)),
-		Content:       pointer.ToString(string(o.content)),
+		Content:       pointer.ToString(string(content)),
 	})
-	if r.StatusCode == http.StatusBadRequest && o.Force {
+	if r != nil && r.StatusCode == http.StatusBadRequest && o.Force {
+		message := err.Error()
 		var repoErr *gitlab.ErrorResponse
-		if !errors.As(err, &repoErr) {
-			repoErr.Message = err.Error()
+		if errors.As(err, &repoErr) {
+			message = repoErr.Message
 		}
-		_, _, err = o.gitlabClient.RepositoryFiles.CreateFile(o.Project, o.path, &gitlab.CreateFileOptions{
-			Branch:        pointer.ToString(branch.Name),
+		_, _, err = o.gitlabClient.RepositoryFiles.CreateFile(pid, o.path, &gitlab.CreateFileOptions{
+			Branch:        pointer.ToString(branch),
 			CommitMessage: pointer.ToString(fmt.Sprintf("create %s from glctl command line", o.path)),
-			Content:       pointer.ToString(string(o.content)),
+			Content:       pointer.ToString(string(content)),
 		})
 
 		if err == nil {
-			s.Warning(" ", repoErr.Message, color.GreenString(" try create new a successfully"))
-			return
+			return replaceCreated, message, nil
+		}
+	}
+	if err != nil {
+		return replaceFailed, "", err
+	}
+	return replaceUpdated, "", nil
+}
+
+// render returns the content of the file for target, the file is used as
+// is unless it is a template.
+func (o *ReplaceOptions) render(target replaceTarget) ([]byte, error) {
+	if o.tmpl == nil {
+		return o.content, nil
+	}
+	return renderTemplate(o.tmpl, &templateData{
+		Branch:  target.branch.Name,
+		Project: target.project,
+		Commit:  target.branch.Commit,
+		Values:  o.values,
+	})
+}
+
+// projects returns the --project or every unarchived project matching the
+// group, topic, search and regex selectors.
+func (o *ReplaceOptions) projects() ([]*gitlab.Project, error) {
+	if o.Project != "" {
+		project, _, err := o.gitlabClient.Projects.GetProject(o.Project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []*gitlab.Project{project}, nil
+	}
+	s := progress.CreatingEvent(true).WithText(" pull projects").Start()
+	defer s.Stop()
+	var projects []*gitlab.Project
+	collect := func(items []*gitlab.Project) error {
+		for _, project := range items {
+			if o.projectRegex == nil || o.projectRegex.MatchString(project.PathWithNamespace) {
+				projects = append(projects, project)
+			}
+		}
+		return nil
+	}
+	var topic, search *string
+	if o.Topic != "" {
+		topic = pointer.ToString(o.Topic)
+	}
+	if o.Search != "" {
+		search = pointer.ToString(o.Search)
+	}
+	var err error
+	if o.Group != "" {
+		opt := &gitlab.ListGroupProjectsOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: cmdutil.DefaultChunkSize,
+			},
+			Archived:         pointer.ToBool(false),
+			IncludeSubGroups: pointer.ToBool(o.IncludeSubgroups),
+			Topic:            topic,
+			Search:           search,
 		}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+				return o.gitlabClient.Groups.ListGroupProjects(o.Group, opt, options...)
+			}, collect)
+	} else {
+		// outside of a group only the projects the user is a member of are
+		// considered, every public project of the instance would match otherwise.
+		opt := &gitlab.ListProjectsOptions{
+			ListOptions: gitlab.ListOptions{
+				Pagination: "keyset",
+				PerPage:    cmdutil.DefaultChunkSize,
+				OrderBy:    "id",
+				Sort:       "asc",
+			},
+			Archived:   pointer.ToBool(false),
+			Membership: pointer.ToBool(true),
+			Topic:      topic,
+			Search:     search,
+		}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+				return o.gitlabClient.Projects.ListProjects(opt, options...)
+			}, collect)
 	}
 	if err != nil {
 		s.Error("Error\n", err.Error())
-		return
+		return nil, err
 	}
-	s.Success()
+	s.Success(fmt.Sprintf("%d projects", len(projects)))
+	return projects, nil
 }
 
-func (o *ReplaceOptions) next() ([]*gitlab.Branch, error) {
+// branches returns the branches of project matching --ref and --ref-match,
+// or its default branch when neither is given. --ref names a branch exactly,
+// the search of the API only narrows the list down as it matches substrings.
+func (o *ReplaceOptions) branches(project *gitlab.Project) ([]*gitlab.Branch, error) {
 	s := progress.CreatingEvent(true).
-		WithText(fmt.Sprintf(" pull branch on page %d", o.branchList.Page)).
+		WithText(fmt.Sprintf(" pull branch of %s", project.PathWithNamespace)).
 		Start()
 	defer s.Stop()
-	branches, _, err := o.gitlabClient.Branches.ListBranches(o.Project, o.branchList)
-	o.branchList.Page++
+	var branches []*gitlab.Branch
+	var err error
+	if strings.TrimSpace(o.Ref) == "" && strings.TrimSpace(o.RefMatch) == "" {
+		branches, err = o.defaultBranch(project)
+	} else {
+		opt := *o.branchList
+		opt.PerPage = cmdutil.DefaultChunkSize
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+				return o.gitlabClient.Branches.ListBranches(project.ID, &opt, options...)
+			}, func(items []*gitlab.Branch) error {
+				for _, branch := range items {
+					if o.Ref == "" || branch.Name == o.Ref {
+						branches = append(branches, branch)
+					}
+				}
+				return nil
+			})
+	}
 	if err != nil {
 		s.Error("Error\n", err.Error())
 		return nil, err
 	}
-	if len(branches) == 0 {
-		s.Done()
-		return branches, nil
-	}
 	s.Success()
 	return branches, nil
 }
+
+// defaultBranch returns the default branch of project, an empty repository
+// has none.
+func (o *ReplaceOptions) defaultBranch(project *gitlab.Project) ([]*gitlab.Branch, error) {
+	if project.DefaultBranch == "" {
+		return nil, nil
+	}
+	branch, _, err := o.gitlabClient.Branches.GetBranch(project.ID, project.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	return []*gitlab.Branch{branch}, nil
+}
+
+// printResults prints the diffs of a dry run and a project by branch matrix
+// of the results followed by the errors of the failed branches.
+func (o *ReplaceOptions) printResults(results []*replaceResult) error {
+	var projects, branches []string
+	seenProjects, seenBranches := map[string]bool{}, map[string]bool{}
+	cells := map[[2]string]string{}
+	var failed, mergeRequests []*replaceResult
+	for _, result := range results {
+		if result.diff != "" {
+			if err := diff.Fprint(o.ioStreams.Out, result.diff); err != nil {
+				return err
+			}
+		}
+		project, branch := result.project.PathWithNamespace, result.branch.Name
+		if !seenProjects[project] {
+			seenProjects[project] = true
+			projects = append(projects, project)
+		}
+		if !seenBranches[branch] {
+			seenBranches[branch] = true
+			branches = append(branches, branch)
+		}
+		cells[[2]string{project, branch}] = result.status
+		if result.mergeRequest != nil {
+			cells[[2]string{project, branch}] = fmt.Sprintf("%s !%d", result.status, result.mergeRequest.IID)
+			mergeRequests = append(mergeRequests, result)
+		}
+		if result.err != nil {
+			failed = append(failed, result)
+		}
+	}
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(o.ioStreams.Out, "no branch matches the given ref")
+		return err
+	}
+	if o.DiffOnly {
+		return o.printFailures(failed, len(results))
+	}
+	err := cmdutil.PrintMatrix(o.ioStreams.Out, "PROJECT", projects, branches, func(project, branch string) string {
+		if status, ok := cells[[2]string{project, branch}]; ok {
+			return status
+		}
+		return "-"
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range mergeRequests {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "%s %s: %s\n",
+			result.project.PathWithNamespace, result.branch.Name, result.mergeRequest.WebURL)
+	}
+	return o.printFailures(failed, len(results))
+}
+
+// printFailures prints the errors of the failed branches out of total.
+func (o *ReplaceOptions) printFailures(failed []*replaceResult, total int) error {
+	for _, result := range failed {
+		_, _ = fmt.Fprintf(o.ioStreams.ErrOut, "%s %s: %v\n",
+			result.project.PathWithNamespace, result.branch.Name, result.err)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to replace %s on %d of %d branches", o.path, len(failed), total)
+	}
+	return nil
+}
@@ -15,11 +15,16 @@
 package file
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
 
+	"github.com/AlekSi/pointer"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
 
 	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
@@ -66,6 +71,71 @@ func TestRunReplace(t *testing.T) {
 			return err
 		},
 		wantError: nil,
+	}, {
+		name: "replace template for all branch",
+		args: []string{"test/template.yaml"},
+		optionsFunc: func(opt *ReplaceOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.RefMatch = "*"
+			opt.FileName = "../../../testdata/replace/template.yaml"
+			opt.Values = "../../../testdata/replace/values.yaml"
+			opt.Force = true
+		},
+		run: func(opt *ReplaceOptions, args []string) error {
+			var err error
+			_ = cmdtesting.Run(func() {
+				err = opt.Run(args)
+			})
+			return err
+		},
+		wantError: nil,
+	}, {
+		name: "replace file across the projects of a group",
+		args: []string{"test/test.yaml"},
+		optionsFunc: func(opt *ReplaceOptions) {
+			opt.Group = "Group2"
+			opt.IncludeSubgroups = true
+			opt.ProjectRegex = "Project13$"
+			opt.RefMatch = "*"
+			opt.FileName = "../../../testdata/replace/new_test.yaml"
+			opt.Parallelism = 2
+		},
+		run: func(opt *ReplaceOptions, args []string) error {
+			var err error
+			_ = cmdtesting.Run(func() {
+				err = opt.Run(args)
+			})
+			return err
+		},
+		wantError: nil,
+	}, {
+		name: "replace file through merge requests",
+		args: []string{"test/test.yaml"},
+		optionsFunc: func(opt *ReplaceOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.Ref = "main"
+			opt.FileName = "../../../testdata/replace/new_test.yaml"
+			opt.mergeRequest.Create = true
+			opt.mergeRequest.Labels = []string{"glctl"}
+		},
+		run: func(opt *ReplaceOptions, args []string) error {
+			var err error
+			_ = cmdtesting.Run(func() {
+				err = opt.Run(args)
+			})
+			return err
+		},
+		wantError: nil,
+	}, {
+		name: "dry run prints the diff of every branch",
+		args: []string{"test/test.yaml"},
+		optionsFunc: func(opt *ReplaceOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.RefMatch = "*"
+			opt.FileName = "../../../testdata/replace/new_test.yaml"
+			opt.DiffOnly = true
+		},
+		wantError: nil,
 	}}
 	streams := genericiooptions.NewTestIOStreamsDiscard()
 	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
@@ -100,3 +170,55 @@ func TestRunReplace(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceBranches(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/1/repository/branches":
+			// the search of the API matches substrings.
+			_, _ = w.Write([]byte(`[{"name":"main"},{"name":"maintenance"},{"name":"domain"}]`))
+		case "/api/v4/projects/1/repository/branches/develop":
+			_, _ = w.Write([]byte(`{"name":"develop"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+	project := &gitlab.Project{ID: 1, PathWithNamespace: "Group1/Project1", DefaultBranch: "develop"}
+	tests := []struct {
+		name    string
+		ref     string
+		project *gitlab.Project
+		want    []string
+	}{{
+		name:    "exact ref",
+		ref:     "main",
+		project: project,
+		want:    []string{"main"},
+	}, {
+		name:    "default branch without a ref",
+		project: project,
+		want:    []string{"develop"},
+	}, {
+		name:    "empty repository without a ref",
+		project: &gitlab.Project{ID: 2, PathWithNamespace: "Group1/Empty"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o := NewReplaceOptions(genericiooptions.NewTestIOStreamsDiscard())
+			o.gitlabClient = client
+			o.Ref = tc.ref
+			o.branchList.Search = pointer.ToString(tc.ref)
+			branches, err := o.branches(tc.project)
+			assert.NoError(t, err)
+			var got []string
+			for _, branch := range branches {
+				got = append(got, branch.Name)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
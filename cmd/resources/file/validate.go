@@ -0,0 +1,109 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"gopkg.in/yaml.v3"
+)
+
+// validator checks the content of a file before it is committed.
+type validator func(content []byte) error
+
+// validatorFor returns the validator of the file at name chosen by its
+// extension, nil when the file is not validated. .gitlab-ci.yml is linted by
+// the CI lint API of project on ref.
+func validatorFor(client *gitlab.Client, project, ref, name string) validator {
+	if path.Base(name) == ".gitlab-ci.yml" {
+		return func(content []byte) error {
+			return lintCI(client, project, ref, content)
+		}
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml":
+		return validateYAML
+	case ".json":
+		return validateJSON
+	case ".toml":
+		return validateTOML
+	}
+	return nil
+}
+
+// validateYAML checks every document of a YAML stream.
+func validateYAML(content []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var v interface{}
+		if err := decoder.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+}
+
+func validateJSON(content []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(content, &v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line := bytes.Count(content[:syntaxErr.Offset], []byte("\n")) + 1
+			return fmt.Errorf("invalid JSON at line %d: %w", line, err)
+		}
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+func validateTOML(content []byte) error {
+	var v map[string]interface{}
+	if err := toml.Unmarshal(content, &v); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, column := decodeErr.Position()
+			return fmt.Errorf("invalid TOML at line %d, column %d: %w", line, column, err)
+		}
+		return fmt.Errorf("invalid TOML: %w", err)
+	}
+	return nil
+}
+
+// lintCI validates a CI/CD configuration in the context of project, so the
+// includes and the variables of ref are resolved.
+func lintCI(client *gitlab.Client, project, ref string, content []byte) error {
+	result, _, err := client.Validate.ProjectNamespaceLint(project, &gitlab.ProjectNamespaceLintOptions{
+		Content: pointer.ToString(string(content)),
+		Ref:     pointer.ToString(ref),
+	})
+	if err != nil {
+		return fmt.Errorf("lint .gitlab-ci.yml: %w", err)
+	}
+	if !result.Valid {
+		return fmt.Errorf("invalid CI/CD configuration:\n%s", strings.Join(result.Errors, "\n"))
+	}
+	return nil
+}
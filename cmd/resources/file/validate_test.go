@@ -0,0 +1,71 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		content   string
+		wantError string
+	}{{
+		name:    "valid yaml stream",
+		path:    "deploy/app.yaml",
+		content: "name: app\n---\nname: db\n",
+	}, {
+		name:      "invalid yaml",
+		path:      "deploy/app.yml",
+		content:   "name: app\n  image: [v1\n",
+		wantError: "invalid YAML: yaml: line 2: mapping values are not allowed in this context",
+	}, {
+		name:    "valid json",
+		path:    "package.json",
+		content: `{"name": "app"}`,
+	}, {
+		name:      "invalid json",
+		path:      "config/app.JSON",
+		content:   "{\n  \"name\": \"app\",\n}\n",
+		wantError: "invalid JSON at line 3: invalid character '}' looking for beginning of object key string",
+	}, {
+		name:    "valid toml",
+		path:    "Cargo.toml",
+		content: "[package]\nname = \"app\"\n",
+	}, {
+		name:      "invalid toml",
+		path:      "Cargo.toml",
+		content:   "[package]\nname = \n",
+		wantError: "invalid TOML at line 2, column 8: toml: incomplete number",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			validate := validatorFor(nil, "", "", tc.path)
+			assert.NotNil(t, validate)
+			err := validate([]byte(tc.content))
+			if tc.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantError)
+		})
+	}
+	assert.Nil(t, validatorFor(nil, "", "", "README.md"))
+	assert.NotNil(t, validatorFor(nil, "", "", "ci/.gitlab-ci.yml"))
+}
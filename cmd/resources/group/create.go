@@ -28,6 +28,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -78,6 +79,7 @@ func NewCreateGroupCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("namespace", completion.GroupCompletionFunc(f)))
 	return cmd
 }
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 )
 
 type DeleteOptions struct {
@@ -64,6 +65,7 @@ func NewDeleteGroupCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Args:                  require.ExactArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.GroupCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -16,6 +16,8 @@ package group
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
@@ -27,6 +29,8 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/util/editor"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -34,6 +38,7 @@ type EditOptions struct {
 	gitlabClient *gitlab.Client
 	groupId      int64
 	Group        *gitlab.UpdateGroupOptions
+	Interactive  bool
 	ioStreams    genericiooptions.IOStreams
 	Out          string
 }
@@ -47,7 +52,10 @@ gctl edit group myGroupAZ --desc="Updated group"
 gctl edit group myGroupX/myGroupZ --desc="Updated group"
 
 # edit a group with id (23)
-gctl edit group 23 --visibility="public`)
+gctl edit group 23 --visibility="public"
+
+# edit the settings of a group as YAML in $EDITOR
+gctl edit group myGroupX -i`)
 )
 
 func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
@@ -68,6 +76,7 @@ func NewEditGroupCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *c
 		Args:                  require.ExactArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.GroupCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -90,6 +99,13 @@ func (o *EditOptions) AddFlags(cmd *cobra.Command) {
 	f.String("path", "",
 		"New group path")
 	f.Bool("lfs-enabled", false, "Enable LFS")
+	f.BoolVarP(
+		&o.Interactive,
+		"interactive",
+		"i",
+		o.Interactive,
+		"Edit the current settings as YAML in the editor, only the changed ones are sent",
+	)
 }
 
 // Complete completes all the required options.
@@ -120,12 +136,18 @@ func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 
 // Validate makes sure there is no discrepency in command options.
 func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Interactive {
+		return validate.ValidateInteractiveFlags(cmd)
+	}
 	err := validate.ValidateVisibilityFlagValue(cmd)
 	return err
 }
 
 // Run executes a list subcommand using the specified options.
 func (o *EditOptions) Run(args []string) error {
+	if o.Interactive {
+		return o.runInteractive()
+	}
 	group, _, err := o.gitlabClient.Groups.UpdateGroup(o.groupId, o.Group)
 	if err != nil {
 		return err
@@ -134,6 +156,35 @@ func (o *EditOptions) Run(args []string) error {
 	return nil
 }
 
+// runInteractive edits the settings of the group in the editor and sends
+// the changed ones.
+func (o *EditOptions) runInteractive() error {
+	current, _, err := o.gitlabClient.Groups.GetGroup(o.groupId, &gitlab.GetGroupOptions{
+		WithProjects: pointer.ToBool(false),
+	})
+	if err != nil {
+		return err
+	}
+	changes, err := editor.NewDefaultEditor([]string{"EDITOR"}).
+		EditFields(fmt.Sprintf("%s-edit-group-", filepath.Base(os.Args[0])), current, o.Group)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(o.ioStreams.ErrOut, "Edit cancelled, no changes made.")
+		return nil
+	}
+	group, _, err := o.gitlabClient.Groups.UpdateGroup(o.groupId, o.Group)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "  %s\n", change)
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "%s configured \n", group.FullPath)
+	return nil
+}
+
 // assign cmd flag to options
 func (o *EditOptions) assignOptions(cmd *cobra.Command) {
 	if cmd.Flag("desc").Changed {
@@ -27,6 +27,7 @@ import (
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -38,6 +39,8 @@ type ListOptions struct {
 	FromGroup    string
 	Out          string
 	AllGroups    bool
+	Limit        int64
+	ChunkSize    int64
 	ioStreams    genericiooptions.IOStreams
 }
 
@@ -67,6 +70,7 @@ func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
 		},
 		groupId:   nil,
 		AllGroups: false,
+		ChunkSize: cmdutil.DefaultChunkSize,
 		Out:       "simple",
 	}
 }
@@ -88,6 +92,7 @@ func NewGetGroupsCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *c
 		SuggestFor: []string{"group"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("group", completion.GroupCompletionFunc(f)))
 	return cmd
 }
 
@@ -99,6 +104,8 @@ func (o *ListOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddSearchVarFlag(cmd, o.group.Search)
 	cmdutil.AddFromGroupVarPFlag(cmd, &o.FromGroup)
 	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
 	f := cmd.Flags()
 	f.BoolVar(o.group.AllAvailable, "all-available", *o.group.AllAvailable, "Show all the groups you have access to "+
 		"(defaults to false for authenticated users, true for admin)")
@@ -146,26 +153,21 @@ func (o *ListOptions) Run(args []string) error {
 		}
 		return cmdutil.PrintGroupsOut(o.Out, o.ioStreams.Out, group)
 	}
-	var groups []*gitlab.Group
-	var err error
+	if o.AllGroups {
+		o.group.PerPage = o.ChunkSize
+		o.group.Page = 1
+	}
+	list := func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+		return o.gitlabClient.Groups.ListGroups(o.group, options...)
+	}
 	if strings.TrimSpace(o.FromGroup) != "" {
-		groups, _, err = o.gitlabClient.Groups.ListSubGroups(o.FromGroup, o.subGroup)
-	} else {
-		for {
-			var portion []*gitlab.Group
-			portion, _, err = o.gitlabClient.Groups.ListGroups(o.group)
-			if err != nil {
-				return nil
-			}
-			groups = append(groups, portion...)
-			if cap(portion) == 0 || !o.AllGroups {
-				break
-			}
-			o.group.Page++
+		list = func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+			return o.gitlabClient.Groups.ListSubGroups(o.FromGroup, o.subGroup, options...)
 		}
 	}
-	if err != nil {
-		return nil
+	printer := cmdutil.NewGroupsPrinter(o.Out, o.ioStreams.Out)
+	if err := cmdutil.ListPages(o.AllGroups, o.Limit, list, printer.PrintChunk); err != nil {
+		return err
 	}
-	return cmdutil.PrintGroupsOut(o.Out, o.ioStreams.Out, groups...)
+	return printer.Flush()
 }
@@ -0,0 +1,178 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	gitlabClient *gitlab.Client
+	issue        *gitlab.CreateIssueOptions
+	project      string
+	Labels       []string
+	Assignees    []string
+	Milestone    string
+	Weight       int64
+	DueDate      string
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	createIssueExample = templates.Examples(`
+# open an issue
+glctl create issue --project=group/myapp --title="Login fails with SSO"
+
+# open a bug assigned to jdoe in the v2 milestone, due at the end of the month
+glctl create issue -p group/myapp --title="Login fails with SSO" --labels=bug,auth \
+  --assignees=jdoe --milestone=v2 --weight=3 --due-date=2026-10-31
+
+# open a confidential issue
+glctl create issue -p group/myapp --title="Token leak" --desc="See the logs of job 42" --confidential`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams: ioStreams,
+		issue: &gitlab.CreateIssueOptions{
+			Title:        pointer.ToString(""),
+			Description:  pointer.ToString(""),
+			Confidential: pointer.ToBool(false),
+		},
+		Out: "simple",
+	}
+}
+
+func NewCreateIssueCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "issue",
+		Aliases:               []string{"i"},
+		Short:                 "Open an issue in a project",
+		Example:               createIssueExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"issues"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVar(o.issue.Title, "title", *o.issue.Title, "The title of the issue")
+	validate.VerifyMarkFlagRequired(cmd, "title")
+	f.StringVar(o.issue.Description, "desc", *o.issue.Description, "The description of the issue")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Comma-separated label names of the issue")
+	f.StringSliceVar(&o.Assignees, "assignees", o.Assignees, "Comma-separated usernames the issue is assigned to")
+	f.StringVar(&o.Milestone, "milestone", o.Milestone, "The title of the milestone of the issue")
+	f.Int64Var(&o.Weight, "weight", o.Weight, "The weight of the issue")
+	f.StringVar(&o.DueDate, "due-date", o.DueDate, "The date the issue is due, as YYYY-MM-DD")
+	f.BoolVar(o.issue.Confidential, "confidential", *o.issue.Confidential,
+		"Only show the issue to the members of the project")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if len(o.Labels) > 0 {
+		o.issue.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+	}
+	if cmd.Flags().Changed("weight") {
+		o.issue.Weight = pointer.ToInt64(o.Weight)
+	}
+	if o.DueDate != "" {
+		if o.issue.DueDate, err = parseDueDate(o.DueDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if strings.TrimSpace(*o.issue.Title) == "" {
+		return cmdutil.UsageErrorf(cmd, "--title can not be empty")
+	}
+	if o.Weight < 0 {
+		return cmdutil.UsageErrorf(cmd, "--weight can not be negative")
+	}
+	return nil
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	if len(o.Assignees) > 0 {
+		ids, err := cmdutil.UserIDs(o.gitlabClient, o.Assignees)
+		if err != nil {
+			return err
+		}
+		o.issue.AssigneeIDs = pointer.To(ids)
+	}
+	if o.Milestone != "" {
+		id, err := milestoneID(o.gitlabClient, o.project, o.Milestone)
+		if err != nil {
+			return err
+		}
+		o.issue.MilestoneID = pointer.ToInt64(id)
+	}
+	issue, _, err := o.gitlabClient.Issues.CreateIssue(o.project, o.issue)
+	if err != nil {
+		return err
+	}
+	return cmdutil.PrintIssuesOut(o.Out, o.ioStreams.Out, issue)
+}
+
+// parseDueDate parses a due date given as YYYY-MM-DD.
+func parseDueDate(value string) (*gitlab.ISOTime, error) {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", value)
+	}
+	return pointer.To(gitlab.ISOTime(date)), nil
+}
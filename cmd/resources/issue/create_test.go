@@ -0,0 +1,132 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreateIssue(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	tests := []struct {
+		name      string
+		flags     []string
+		run       func(opt *CreateOptions, args []string) error
+		wantError error
+	}{{
+		name:      "empty title",
+		flags:     []string{"-p=" + project, "--title= "},
+		wantError: errors.New("--title can not be empty\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "negative weight",
+		flags:     []string{"-p=" + project, "--title=glctl create issue", "--weight=-1"},
+		wantError: errors.New("--weight can not be negative\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "invalid due date",
+		flags:     []string{"-p=" + project, "--title=glctl create issue", "--due-date=31.10.2026"},
+		wantError: errors.New(`invalid due date "31.10.2026", expected YYYY-MM-DD`),
+	}, {
+		name:      "milestone not found",
+		flags:     []string{"-p=" + project, "--title=glctl create issue", "--milestone=glctl-missing"},
+		wantError: errors.New("milestone glctl-missing not found in project " + project),
+	}, {
+		name:  "open an issue",
+		flags: []string{"-p=" + project, "--title=glctl create issue", "--labels=bug", "--weight=3", "--due-date=2026-10-31"},
+		run: func(opt *CreateOptions, args []string) error {
+			t.Cleanup(func() {
+				deleteTestIssues(t, opt.gitlabClient, project, "glctl create issue")
+			})
+			var err error
+			out := cmdtesting.RunForStdout(opt.ioStreams, func() {
+				err = opt.Run(args)
+			})
+			assert.Contains(t, out, "opened")
+			assert.Contains(t, out, "glctl create issue")
+			return err
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "issue"}
+			cmdOptions := NewCreateOptions(genericiooptions.NewTestIOStreamsForPipe())
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.run != nil {
+				err = tc.run(cmdOptions, nil)
+			} else {
+				err = cmdOptions.Run(nil)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+// createTestIssue opens an issue in project, it is deleted again when the
+// test ends.
+func createTestIssue(t *testing.T, client *gitlab.Client, project, title string) *gitlab.Issue {
+	t.Helper()
+	issue, _, err := client.Issues.CreateIssue(project, &gitlab.CreateIssueOptions{
+		Title: pointer.ToString(title),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = client.Issues.DeleteIssue(project, issue.IID)
+	})
+	return issue
+}
+
+// deleteTestIssues deletes the issues of project with the given title.
+func deleteTestIssues(t *testing.T, client *gitlab.Client, project, title string) {
+	t.Helper()
+	issues, _, err := client.Issues.ListProjectIssues(project, &gitlab.ListProjectIssuesOptions{
+		Search: pointer.ToString(title),
+		In:     pointer.ToString("title"),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, issue := range issues {
+		if issue.Title == title {
+			_, _ = client.Issues.DeleteIssue(project, issue.IID)
+		}
+	}
+}
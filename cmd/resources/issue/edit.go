@@ -0,0 +1,393 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type EditOptions struct {
+	gitlabClient *gitlab.Client
+	issue        *gitlab.UpdateIssueOptions
+	filter       *issueFilter
+	project      string
+	iid          int64
+	Title        string
+	Description  string
+	Labels       []string
+	AddLabels    []string
+	RemoveLabels []string
+	Assignees    []string
+	Milestone    string
+	Weight       int64
+	DueDate      string
+	Confidential bool
+	Selector     string
+	Parallelism  int
+	DryRun       bool
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	editIssueExample = templates.Examples(`
+# change the title of an issue
+glctl edit issue group/myapp#12 --title="Login fails with SSO"
+
+# label an issue of the project given with --project and plan it for v2
+glctl edit issue 12 -p group/myapp --add-labels=triaged --remove-labels=needs-triage --milestone=v2
+
+# unassign an issue and remove its due date and weight
+glctl edit issue group/myapp#12 --assignees="" --due-date="" --weight=-1
+
+# triage every opened bug of a project, 5 issues at a time
+glctl edit issues -p group/myapp --selector=label=bug,state=opened --add-label=triaged --milestone=v2 --parallelism=5
+
+# list the issues a bulk edit would change without changing them
+glctl edit issues -p group/myapp --selector=label=bug,assignee=none --assignees=jdoe --dry-run`)
+
+	editIssueFlags = []string{
+		"title", "desc", "labels", "add-labels", "remove-labels", "assignees", "milestone", "weight", "due-date",
+		"confidential",
+	}
+
+	// labelFlagAliases accepts the singular --add-label and --remove-label
+	// for their plural flags.
+	labelFlagAliases = map[string]string{
+		"add-label":    "add-labels",
+		"remove-label": "remove-labels",
+	}
+)
+
+const (
+	editEdited    = "edited"
+	editWouldEdit = "would edit"
+	editFailed    = "failed"
+)
+
+func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
+	return &EditOptions{
+		ioStreams:   ioStreams,
+		issue:       &gitlab.UpdateIssueOptions{},
+		Parallelism: 10,
+		Out:         "simple",
+	}
+}
+
+func NewEditIssueCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewEditOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "issue",
+		Aliases:               []string{"issues", "i"},
+		Short:                 "Edit an issue, or every issue of a project matching a selector",
+		Example:               editIssueExample,
+		Args:                  require.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.IssueCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *EditOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	f := cmd.Flags()
+	f.StringVar(&o.Title, "title", o.Title, "The new title of the issue")
+	f.StringVar(&o.Description, "desc", o.Description, "The new description of the issue")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Comma-separated label names replacing the labels of the issue")
+	f.StringSliceVar(&o.AddLabels, "add-labels", o.AddLabels, "Comma-separated label names added to the issue")
+	f.StringSliceVar(&o.RemoveLabels, "remove-labels", o.RemoveLabels, "Comma-separated label names removed from the issue")
+	for alias, name := range labelFlagAliases {
+		f.StringSlice(alias, nil, "Same as --"+name)
+		cmdutil.CheckErr(f.MarkHidden(alias))
+	}
+	f.StringSliceVar(&o.Assignees, "assignees", o.Assignees,
+		"Comma-separated usernames replacing the assignees of the issue, empty to unassign it")
+	f.StringVar(&o.Milestone, "milestone", o.Milestone, "The title of the new milestone of the issue, empty to remove it")
+	f.Int64Var(&o.Weight, "weight", o.Weight, "The new weight of the issue, -1 to remove it")
+	f.StringVar(&o.DueDate, "due-date", o.DueDate, "The new date the issue is due, as YYYY-MM-DD, empty to remove it")
+	f.BoolVar(&o.Confidential, "confidential", o.Confidential, "Only show the issue to the members of the project")
+	f.StringVar(&o.Selector, "selector", o.Selector,
+		fmt.Sprintf("Edit every issue of --project matching these comma-separated key=value pairs (%s)",
+			strings.Join(selectorKeys, ", ")))
+	f.IntVar(&o.Parallelism, "parallelism", o.Parallelism, "The number of issues edited at the same time with --selector.")
+	f.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"If true, only list the issues matching --selector, nothing is edited.",
+	)
+}
+
+// Complete completes all the required options.
+func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if err = mergeFlagAliases(cmd.Flags(), labelFlagAliases); err != nil {
+		return err
+	}
+	if o.Selector != "" {
+		if o.filter, err = parseSelector(o.Selector); err != nil {
+			return err
+		}
+	} else if len(args) > 0 {
+		if o.project, o.iid, err = parseReference(args[0], o.project); err != nil {
+			return err
+		}
+	}
+	return o.assignOptions(cmd)
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Selector == "" {
+		if len(args) == 0 {
+			return cmdutil.UsageErrorf(cmd, "an issue or --selector is required")
+		}
+		if cmd.Flags().Changed("parallelism") || o.DryRun {
+			return cmdutil.UsageErrorf(cmd, "--parallelism and --dry-run require --selector")
+		}
+	} else {
+		if len(args) > 0 {
+			return cmdutil.UsageErrorf(cmd, "an issue can not be combined with --selector")
+		}
+		if strings.TrimSpace(o.project) == "" {
+			return cmdutil.UsageErrorf(cmd, "--selector requires --project")
+		}
+		if o.Parallelism < 1 {
+			return cmdutil.UsageErrorf(cmd, "--parallelism must be at least 1")
+		}
+	}
+	if o.Weight < -1 {
+		return cmdutil.UsageErrorf(cmd, "--weight can not be negative, use -1 to remove it")
+	}
+	for _, name := range editIssueFlags {
+		if cmd.Flags().Changed(name) {
+			return nil
+		}
+	}
+	return cmdutil.UsageErrorf(cmd, "nothing to edit, use at least one of --%s", strings.Join(editIssueFlags, ", --"))
+}
+
+// Run executes a edit subcommand using the specified options.
+func (o *EditOptions) Run(args []string) error {
+	if o.Milestone != "" {
+		id, err := milestoneID(o.gitlabClient, o.project, o.Milestone)
+		if err != nil {
+			return err
+		}
+		o.issue.MilestoneID = pointer.ToInt64(id)
+	}
+	if o.Selector != "" {
+		return o.runSelector()
+	}
+	issue, _, err := o.gitlabClient.Issues.UpdateIssue(o.project, o.iid, o.issue)
+	if err != nil {
+		return err
+	}
+	if o.Out != "simple" {
+		return cmdutil.PrintIssuesOut(o.Out, o.ioStreams.Out, issue)
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "issue %s edited\n", displayReference(o.project, issue))
+	return nil
+}
+
+// mergeFlagAliases appends the values of the changed alias flags to the
+// flags they stand for.
+func mergeFlagAliases(flags *pflag.FlagSet, aliases map[string]string) error {
+	for alias, name := range aliases {
+		if !flags.Changed(alias) {
+			continue
+		}
+		values, err := flags.GetStringSlice(alias)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			if err = flags.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type editResult struct {
+	issue  *gitlab.Issue
+	status string
+	err    error
+}
+
+// runSelector edits the issues matching the selector with at most
+// --parallelism of them at the same time, and prints a summary of the
+// edited issues followed by the errors of the failed ones.
+func (o *EditOptions) runSelector() error {
+	issues, err := o.selectIssues()
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		_, err = fmt.Fprintln(o.ioStreams.Out, "no issue matches the selector")
+		return err
+	}
+	results := make([]*editResult, len(issues))
+	var wg = sync.WaitGroup{}
+	limit := make(chan struct{}, o.Parallelism)
+	for i, issue := range issues {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, issue *gitlab.Issue) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			results[i] = o.edit(issue)
+		}(i, issue)
+	}
+	wg.Wait()
+	return o.printResults(results)
+}
+
+// selectIssues returns every issue of the project matching the selector.
+func (o *EditOptions) selectIssues() ([]*gitlab.Issue, error) {
+	opt := o.filter.listOptions()
+	opt.PerPage = cmdutil.DefaultChunkSize
+	var issues []*gitlab.Issue
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Issue, *gitlab.Response, error) {
+			return o.gitlabClient.Issues.ListProjectIssues(o.project, opt, options...)
+		}, func(items []*gitlab.Issue) error {
+			issues = append(issues, filterIssues(o.filter, items)...)
+			return nil
+		})
+	return issues, err
+}
+
+func (o *EditOptions) edit(issue *gitlab.Issue) *editResult {
+	if o.DryRun {
+		return &editResult{issue: issue, status: editWouldEdit}
+	}
+	edited, _, err := o.gitlabClient.Issues.UpdateIssue(issue.ProjectID, issue.IID, o.issue)
+	if err != nil {
+		return &editResult{issue: issue, status: editFailed, err: err}
+	}
+	return &editResult{issue: edited, status: editEdited}
+}
+
+// printResults prints an issue by issue summary and the errors of the
+// failed issues.
+func (o *EditOptions) printResults(results []*editResult) error {
+	printer := cmdutil.NewListPrinter("simple", o.ioStreams.Out, []string{"ISSUE", "RESULT", "TITLE"},
+		func(r *editResult) []string {
+			return []string{displayReference(o.project, r.issue), r.status, r.issue.Title}
+		})
+	if err := printer.PrintChunk(results); err != nil {
+		return err
+	}
+	if err := printer.Flush(); err != nil {
+		return err
+	}
+	var failed int
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			_, _ = fmt.Fprintf(o.ioStreams.ErrOut, "%s: %v\n", displayReference(o.project, r.issue), r.err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to edit %d of %d issues", failed, len(results))
+	}
+	return nil
+}
+
+// assign the changed flags to the update options, the usernames are
+// resolved to ids. The milestone is resolved by Run, once Validate made sure
+// the project is known.
+func (o *EditOptions) assignOptions(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if flags.Changed("title") {
+		o.issue.Title = pointer.ToString(o.Title)
+	}
+	if flags.Changed("desc") {
+		o.issue.Description = pointer.ToString(o.Description)
+	}
+	if flags.Changed("labels") {
+		o.issue.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+	}
+	if flags.Changed("add-labels") {
+		o.issue.AddLabels = pointer.To(gitlab.LabelOptions(o.AddLabels))
+	}
+	if flags.Changed("remove-labels") {
+		o.issue.RemoveLabels = pointer.To(gitlab.LabelOptions(o.RemoveLabels))
+	}
+	if flags.Changed("confidential") {
+		o.issue.Confidential = pointer.ToBool(o.Confidential)
+	}
+	if flags.Changed("weight") {
+		if o.Weight == -1 {
+			o.issue.ResetWeight = true
+		} else {
+			o.issue.Weight = pointer.ToInt64(o.Weight)
+		}
+	}
+	if flags.Changed("due-date") {
+		if o.DueDate == "" {
+			o.issue.ResetDueDate = true
+		} else {
+			date, err := parseDueDate(o.DueDate)
+			if err != nil {
+				return err
+			}
+			o.issue.DueDate = date
+		}
+	}
+	if flags.Changed("assignees") {
+		ids, err := cmdutil.UserIDs(o.gitlabClient, o.Assignees)
+		if err != nil {
+			return err
+		}
+		o.issue.AssigneeIDs = pointer.To(ids)
+	}
+	if flags.Changed("milestone") && o.Milestone == "" {
+		o.issue.ResetMilestoneID = true
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateEditIssue(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantError error
+	}{{
+		name:  "edit an issue",
+		args:  []string{"Group2/SubGroup3/Project13#1"},
+		flags: []string{"--add-label=triaged", "--weight=-1"},
+	}, {
+		name:  "edit the issues matching a selector",
+		flags: []string{"-p=Group2/SubGroup3/Project13", "--selector=label=bug", "--milestone=v2", "--dry-run"},
+	}, {
+		name: "nothing to edit",
+		args: []string{"Group2/SubGroup3/Project13#1"},
+		wantError: errors.New("nothing to edit, use at least one of --title, --desc, --labels, --add-labels, " +
+			"--remove-labels, --assignees, --milestone, --weight, --due-date, --confidential\n" +
+			"See 'issue -h' for help and examples"),
+	}, {
+		name:      "missing issue",
+		flags:     []string{"--title=x"},
+		wantError: errors.New("an issue or --selector is required\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "issue and selector",
+		args:      []string{"Group2/SubGroup3/Project13#1"},
+		flags:     []string{"-p=Group2/SubGroup3/Project13", "--selector=label=bug", "--title=x"},
+		wantError: errors.New("an issue can not be combined with --selector\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "selector without project",
+		flags:     []string{"--selector=label=bug", "--title=x"},
+		wantError: errors.New("--selector requires --project\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "milestone with a selector without project",
+		flags:     []string{"--selector=label=bug", "--milestone=v2"},
+		wantError: errors.New("--selector requires --project\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "dry run without selector",
+		args:      []string{"Group2/SubGroup3/Project13#1"},
+		flags:     []string{"--title=x", "--dry-run"},
+		wantError: errors.New("--parallelism and --dry-run require --selector\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "no parallelism",
+		flags:     []string{"-p=Group2/SubGroup3/Project13", "--selector=label=bug", "--title=x", "--parallelism=0"},
+		wantError: errors.New("--parallelism must be at least 1\nSee 'issue -h' for help and examples"),
+	}, {
+		name:      "negative weight",
+		args:      []string{"Group2/SubGroup3/Project13#1"},
+		flags:     []string{"--weight=-2"},
+		wantError: errors.New("--weight can not be negative, use -1 to remove it\nSee 'issue -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "issue"}
+			cmdOptions := NewEditOptions(genericiooptions.NewTestIOStreamsDiscard())
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			if err := mergeFlagAliases(cmd.Flags(), labelFlagAliases); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
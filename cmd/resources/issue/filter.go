@@ -0,0 +1,156 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var (
+	issueStates = []string{"opened", "closed", "all"}
+
+	// dueDateFilters are the values the server accepts to filter issues by
+	// due date, none stands for the issues without one.
+	dueDateFilters = []string{"none", "any", "today", "tomorrow", "overdue", "week", "month",
+		"next_month_and_previous_two_weeks"}
+
+	selectorKeys = []string{"label", "state", "milestone", "assignee", "author", "weight", "due-date", "search"}
+)
+
+// issueFilter selects the issues of a project, it is set by the filter
+// flags of get issues and by the --selector of edit issues.
+type issueFilter struct {
+	State     string
+	Labels    []string
+	Milestone string
+	Assignee  string
+	Author    string
+	Weight    *int64
+	DueDate   string
+	Search    string
+}
+
+// parseSelector parses a selector made of comma-separated key=value pairs,
+// such as label=bug,state=opened. The label key may be repeated and only
+// the opened issues are selected unless state is given.
+func parseSelector(selector string) (*issueFilter, error) {
+	filter := &issueFilter{State: "opened"}
+	for _, pair := range strings.Split(selector, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid selector %q, expected key=value pairs separated by commas", selector)
+		}
+		switch key {
+		case "label":
+			filter.Labels = append(filter.Labels, value)
+		case "state":
+			filter.State = value
+		case "milestone":
+			filter.Milestone = value
+		case "assignee":
+			filter.Assignee = value
+		case "author":
+			filter.Author = value
+		case "weight":
+			weight, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight %q in selector, expected a number", value)
+			}
+			filter.Weight = pointer.ToInt64(weight)
+		case "due-date":
+			filter.DueDate = value
+		case "search":
+			filter.Search = value
+		default:
+			return nil, fmt.Errorf("unknown selector key %q, use one of %s", key, strings.Join(selectorKeys, ", "))
+		}
+	}
+	return filter, filter.validate()
+}
+
+// validate checks the values the server would otherwise reject or ignore.
+func (f *issueFilter) validate() error {
+	if !slices.Contains(issueStates, f.State) {
+		return fmt.Errorf("invalid state %q, use one of %s", f.State, strings.Join(issueStates, ", "))
+	}
+	if f.DueDate != "" && !slices.Contains(dueDateFilters, f.DueDate) {
+		return fmt.Errorf("invalid due date %q, use one of %s", f.DueDate, strings.Join(dueDateFilters, ", "))
+	}
+	return nil
+}
+
+// listOptions returns the options listing the issues selected by the filter
+// on the server, the weight is matched by match.
+func (f *issueFilter) listOptions() *gitlab.ListProjectIssuesOptions {
+	opt := &gitlab.ListProjectIssuesOptions{
+		State: pointer.ToString(f.State),
+	}
+	if len(f.Labels) > 0 {
+		opt.Labels = pointer.To(gitlab.LabelOptions(f.Labels))
+	}
+	if f.Milestone != "" {
+		opt.Milestone = pointer.ToString(f.Milestone)
+	}
+	switch assignee := strings.TrimPrefix(f.Assignee, "@"); strings.ToLower(assignee) {
+	case "":
+	case "none":
+		opt.AssigneeID = gitlab.AssigneeID(gitlab.UserIDNone)
+	case "any":
+		opt.AssigneeID = gitlab.AssigneeID(gitlab.UserIDAny)
+	default:
+		opt.AssigneeUsername = pointer.ToString(assignee)
+	}
+	if f.Author != "" {
+		opt.AuthorUsername = pointer.ToString(strings.TrimPrefix(f.Author, "@"))
+	}
+	switch f.DueDate {
+	case "":
+	case "none":
+		opt.DueDate = pointer.ToString("0")
+	default:
+		opt.DueDate = pointer.ToString(f.DueDate)
+	}
+	if f.Search != "" {
+		opt.Search = pointer.ToString(f.Search)
+	}
+	return opt
+}
+
+// match reports whether the issue has the weight of the filter, the
+// server has no such filter for every edition.
+func (f *issueFilter) match(issue *gitlab.Issue) bool {
+	return f.Weight == nil || issue.Weight == *f.Weight
+}
+
+// filterIssues keeps the issues matching filter.
+func filterIssues(filter *issueFilter, issues []*gitlab.Issue) []*gitlab.Issue {
+	var matched []*gitlab.Issue
+	for _, issue := range issues {
+		if filter.match(issue) {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		want      *gitlab.ListProjectIssuesOptions
+		wantError error
+	}{{
+		name:     "opened issues by default",
+		selector: "label=bug",
+		want: &gitlab.ListProjectIssuesOptions{
+			State:  pointer.ToString("opened"),
+			Labels: pointer.To(gitlab.LabelOptions{"bug"}),
+		},
+	}, {
+		name:     "every key",
+		selector: "label=bug, label=ui,state=all,milestone=v2,assignee=@jdoe,author=root,due-date=none,search=login",
+		want: &gitlab.ListProjectIssuesOptions{
+			State:            pointer.ToString("all"),
+			Labels:           pointer.To(gitlab.LabelOptions{"bug", "ui"}),
+			Milestone:        pointer.ToString("v2"),
+			AssigneeUsername: pointer.ToString("jdoe"),
+			AuthorUsername:   pointer.ToString("root"),
+			DueDate:          pointer.ToString("0"),
+			Search:           pointer.ToString("login"),
+		},
+	}, {
+		name:     "unassigned issues",
+		selector: "assignee=none",
+		want: &gitlab.ListProjectIssuesOptions{
+			State:      pointer.ToString("opened"),
+			AssigneeID: gitlab.AssigneeID(gitlab.UserIDNone),
+		},
+	}, {
+		name:      "missing value",
+		selector:  "label",
+		wantError: errors.New(`invalid selector "label", expected key=value pairs separated by commas`),
+	}, {
+		name:     "unknown key",
+		selector: "labels=bug",
+		wantError: errors.New(`unknown selector key "labels", ` +
+			`use one of label, state, milestone, assignee, author, weight, due-date, search`),
+	}, {
+		name:      "invalid weight",
+		selector:  "weight=heavy",
+		wantError: errors.New(`invalid weight "heavy" in selector, expected a number`),
+	}, {
+		name:      "invalid state",
+		selector:  "state=merged",
+		wantError: errors.New(`invalid state "merged", use one of opened, closed, all`),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := parseSelector(tc.selector)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, filter.listOptions())
+		})
+	}
+}
+
+func TestFilterIssues(t *testing.T) {
+	issues := []*gitlab.Issue{{IID: 1, Weight: 0}, {IID: 2, Weight: 2}, {IID: 3, Weight: 2}}
+	filter, err := parseSelector("weight=2")
+	assert.NoError(t, err)
+	assert.Equal(t, []*gitlab.Issue{issues[1], issues[2]}, filterIssues(filter, issues))
+
+	filter, err = parseSelector("label=bug")
+	assert.NoError(t, err)
+	assert.Len(t, filterIssues(filter, issues), 3)
+}
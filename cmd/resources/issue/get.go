@@ -0,0 +1,173 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type ListOptions struct {
+	gitlabClient *gitlab.Client
+	Out          string
+	issue        *gitlab.ListProjectIssuesOptions
+	filter       *issueFilter
+	Weight       int64
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	getIssuesExample = templates.Examples(`
+# list the opened issues of a project
+glctl get issues group1/devops
+
+# list the closed bugs of a milestone
+glctl get issues group1/devops --state=closed --labels=bug --milestone=v2
+
+# list every unassigned issue that is overdue
+glctl get issues 100 --assignee=none --due-date=overdue -A
+
+# list the issues of jdoe with a weight of 3 as json
+glctl get issues 100 --assignee=jdoe --weight=3 -o json`)
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		issue: &gitlab.ListProjectIssuesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 10,
+			},
+		},
+		filter:    &issueFilter{State: "opened"},
+		All:       false,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+func NewGetIssuesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "issues [Project]",
+		Aliases:               []string{"issue", "i"},
+		Short:                 "List the issues of a project",
+		Example:               getIssuesExample,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Args:                  require.ExactArgs(1),
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"tickets"},
+	}
+	o.AddFlags(cmd)
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddPaginationVarFlags(cmd, &o.issue.ListOptions)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.StringVar(&o.filter.State, "state", o.filter.State,
+		fmt.Sprintf("Only list the issues in this state (%s)", strings.Join(issueStates, ", ")))
+	f.StringSliceVar(&o.filter.Labels, "labels", o.filter.Labels, "Only list the issues with all of these comma-separated labels")
+	f.StringVar(&o.filter.Milestone, "milestone", o.filter.Milestone,
+		"Only list the issues of the milestone with this title, None or Any")
+	f.StringVar(&o.filter.Assignee, "assignee", o.filter.Assignee,
+		"Only list the issues assigned to this username, none for the unassigned ones or any")
+	f.StringVar(&o.filter.Author, "author", o.filter.Author, "Only list the issues created by this username")
+	f.Int64Var(&o.Weight, "weight", o.Weight, "Only list the issues with this weight")
+	f.StringVar(&o.filter.DueDate, "due-date", o.filter.DueDate,
+		fmt.Sprintf("Only list the issues due in this period (%s)", strings.Join(dueDateFilters, ", ")))
+	f.StringVar(&o.filter.Search, "search", o.filter.Search, "Only list the issues with this text in their title or description")
+	f.BoolVarP(
+		&o.All,
+		"all",
+		"A",
+		o.All,
+		"If present, list all the issues of the project, page by page.",
+	)
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("weight") {
+		o.filter.Weight = pointer.ToInt64(o.Weight)
+	}
+	listOptions := o.issue.ListOptions
+	o.issue = o.filter.listOptions()
+	o.issue.ListOptions = listOptions
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && strings.TrimSpace(args[0]) == "" {
+		return fmt.Errorf("error from server (NotFound): project %s not found", args[0])
+	}
+	if err := validate.ValidateFlagStringValue(issueStates, cmd, "state"); err != nil {
+		return err
+	}
+	if o.filter.DueDate == "" {
+		return nil
+	}
+	return validate.ValidateFlagStringValue(dueDateFilters, cmd, "due-date")
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	if o.All {
+		o.issue.PerPage = o.ChunkSize
+		o.issue.Page = 1
+	}
+	printer := cmdutil.NewIssuesPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Issue, *gitlab.Response, error) {
+			issues, resp, err := o.gitlabClient.Issues.ListProjectIssues(args[0], o.issue, options...)
+			return filterIssues(o.filter, issues), resp, err
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
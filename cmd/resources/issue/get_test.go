@@ -0,0 +1,86 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetIssues(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantError error
+	}{{
+		name:      "project name is an empty string",
+		args:      []string{""},
+		wantError: errors.New("error from server (NotFound): project  not found"),
+	}, {
+		name:  "invalid state",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--state=merged"},
+		wantError: errors.New("'merged' is not a recognized value of 'state' flag; " +
+			"choose from [opened, closed, all]"),
+	}, {
+		name:  "invalid due date",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--due-date=yesterday"},
+		wantError: errors.New("'yesterday' is not a recognized value of 'due-date' flag; " +
+			"choose from [none, any, today, tomorrow, overdue, week, month, next_month_and_previous_two_weeks]"),
+	}, {
+		name:  "list the opened issues",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--all"},
+	}, {
+		name: "list the unassigned bugs of a milestone with a weight",
+		args: []string{"Group2/SubGroup3/Project13"},
+		flags: []string{
+			"--state=all", "--labels=bug", "--milestone=Any", "--assignee=none", "--weight=1", "--due-date=any", "--out=json",
+		},
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "issues"}
+			cmdOptions := NewListOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
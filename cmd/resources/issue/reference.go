@@ -0,0 +1,70 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// parseReference splits an issue reference of the form <project>#<iid> into
+// the project and the iid. The project may be left out, as in #<iid> or
+// <iid>, and is then taken from project.
+func parseReference(ref, project string) (string, int64, error) {
+	pid, id := project, ref
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		if i > 0 {
+			pid = ref[:i]
+		}
+		id = ref[i+1:]
+	}
+	iid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || iid <= 0 {
+		return "", 0, fmt.Errorf("invalid issue %q, expected <project>#<iid> or <iid> with --project", ref)
+	}
+	if strings.TrimSpace(pid) == "" {
+		return "", 0, fmt.Errorf("the project of issue %q is missing, use <project>#<iid> or --project", ref)
+	}
+	return pid, iid, nil
+}
+
+// displayReference returns the full reference of the issue, which names its
+// project.
+func displayReference(pid string, issue *gitlab.Issue) string {
+	if issue.References != nil && issue.References.Full != "" {
+		return issue.References.Full
+	}
+	return fmt.Sprintf("%s#%d", pid, issue.IID)
+}
+
+// milestoneID resolves the title of a milestone of the project, or of one
+// of its groups, to its id.
+func milestoneID(client *gitlab.Client, pid, title string) (int64, error) {
+	milestones, _, err := client.Milestones.ListMilestones(pid, &gitlab.ListMilestonesOptions{
+		Title:            pointer.ToString(title),
+		IncludeAncestors: pointer.ToBool(true),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(milestones) == 0 {
+		return 0, fmt.Errorf("milestone %s not found in project %s", title, pid)
+	}
+	return milestones[0].ID, nil
+}
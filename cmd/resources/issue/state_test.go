@@ -0,0 +1,62 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCloseAndReopenIssue(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := createTestIssue(t, client, project, "glctl close issue")
+	for _, tc := range []struct {
+		event string
+		want  string
+	}{{
+		event: "close",
+		want:  "closed",
+	}, {
+		event: "reopen",
+		want:  "opened",
+	}} {
+		t.Run(tc.event, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			o := NewStateOptions(streams, tc.event)
+			cmd := NewCloseIssueCmd(factory, streams)
+			args := []string{issue.References.Full}
+			if err := o.Complete(factory, cmd, args); err != nil {
+				t.Fatal(err)
+			}
+			var err error
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = o.Run(args)
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, out, tc.want)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// ActionOptions retries, cancels or plays a job, depending on the action.
+type ActionOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	id           int64
+	action       string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	retryJobExample = templates.Examples(`
+# retry a failed job
+glctl retry job group/myapp 81234`)
+
+	cancelJobExample = templates.Examples(`
+# cancel a running job
+glctl cancel job group/myapp 81234`)
+
+	playJobExample = templates.Examples(`
+# start a manual job
+glctl play job group/myapp 81234`)
+)
+
+func NewActionOptions(ioStreams genericiooptions.IOStreams, action string) *ActionOptions {
+	return &ActionOptions{
+		ioStreams: ioStreams,
+		action:    action,
+	}
+}
+
+func NewRetryJobCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return newActionCmd(f, NewActionOptions(ioStreams, "retry"), "Retry a job", retryJobExample)
+}
+
+func NewCancelJobCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return newActionCmd(f, NewActionOptions(ioStreams, "cancel"), "Cancel a job", cancelJobExample)
+}
+
+func NewPlayJobCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return newActionCmd(f, NewActionOptions(ioStreams, "play"), "Start a manual job", playJobExample)
+}
+
+func newActionCmd(f cmdutil.Factory, o *ActionOptions, short, example string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "job",
+		Short:                 short,
+		Example:               example,
+		Args:                  jobArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"jobs"},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *ActionOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.id, err = parseJob(args, o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ActionOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Run executes a retry, cancel or play subcommand using the specified options.
+func (o *ActionOptions) Run(args []string) error {
+	var (
+		job *gitlab.Job
+		err error
+	)
+	switch o.action {
+	case "retry":
+		job, _, err = o.gitlabClient.Jobs.RetryJob(o.project, o.id)
+	case "cancel":
+		job, _, err = o.gitlabClient.Jobs.CancelJob(o.project, o.id)
+	case "play":
+		job, _, err = o.gitlabClient.Jobs.PlayJob(o.project, o.id, &gitlab.PlayJobOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	if job.ID != o.id {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "job #%d retried as #%d %s\n", o.id, job.ID, job.Status)
+		return nil
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "job #%d %s\n", job.ID, job.Status)
+	return nil
+}
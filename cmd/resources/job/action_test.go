@@ -0,0 +1,90 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRunActionJob(t *testing.T) {
+	// a retried job is a new job, a canceled or played job keeps its id.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch path.Base(r.URL.Path) {
+		case "retry":
+			_, _ = w.Write([]byte(`{"id":81235,"status":"pending"}`))
+		case "cancel":
+			_, _ = w.Write([]byte(`{"id":81234,"status":"canceled"}`))
+		case "play":
+			_, _ = w.Write([]byte(`{"id":81234,"status":"pending"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Not found"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+	tests := []struct {
+		action string
+		want   string
+	}{{
+		action: "retry",
+		want:   "job #81234 retried as #81235 pending\n",
+	}, {
+		action: "cancel",
+		want:   "job #81234 canceled\n",
+	}, {
+		action: "play",
+		want:   "job #81234 pending\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.action, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			o := NewActionOptions(streams, tc.action)
+			o.gitlabClient, o.project, o.id = client, "group/myapp", 81234
+			var err error
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = o.Run(nil)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestCompleteActionJob(t *testing.T) {
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	cmd := NewRetryJobCmd(factory, streams)
+	o := NewActionOptions(streams, "retry")
+	if err := o.Complete(factory, cmd, []string{"Group2/SubGroup3/Project13", "1"}); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, o.Validate(cmd, nil))
+	assert.Equal(t, "Group2/SubGroup3/Project13", o.project)
+	assert.Equal(t, int64(1), o.id)
+}
@@ -0,0 +1,317 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/archive"
+	"github.com/huhouhua/glctl/pkg/util/progress"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+	"github.com/huhouhua/glctl/pkg/util/term"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type ArtifactsOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	Job          string
+	Ref          string
+	Path         string
+	Output       string
+	Extract      string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	getArtifactsExample = templates.Examples(`
+# download the artifacts of the build job of the last successful pipeline on main
+glctl get artifacts group/myapp --job=build --ref=main -o build.zip
+
+# download a single file of the artifacts
+glctl get artifacts group/myapp --job=build --ref=v1.2.0 --path=dist/app.tar.gz
+
+# extract the artifacts into ./dist
+glctl get artifacts group/myapp --job=build --ref=main --extract=./dist`)
+)
+
+func NewArtifactsOptions(ioStreams genericiooptions.IOStreams) *ArtifactsOptions {
+	return &ArtifactsOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewGetArtifactsCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewArtifactsOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "artifacts",
+		Aliases:               []string{"artifact"},
+		Short:                 "download the artifacts of a job of the last successful pipeline on a ref",
+		Example:               getArtifactsExample,
+		DisableFlagsInUseLine: true,
+		Args:                  require.ExactArgs(1),
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+func (o *ArtifactsOptions) AddFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringVar(&o.Job, "job", o.Job, "The name of the job which created the artifacts.")
+	validate.VerifyMarkFlagRequired(cmd, "job")
+	f.StringVar(&o.Ref, "ref", o.Ref, "The branch or tag of the pipeline or, if not given, the default branch.")
+	f.StringVar(&o.Path, "path", o.Path, "The path of a single file of the artifacts to download.")
+	f.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"The file the artifacts are written to, - writes them to stdout. Default is named after the project and job.",
+	)
+	f.StringVar(&o.Extract, "extract", o.Extract, "Extract the artifacts into this directory instead of keeping the archive.")
+}
+
+// Complete completes all the required options.
+func (o *ArtifactsOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	o.project = args[0]
+	o.Path = strings.Trim(path.Clean("/"+strings.TrimSpace(o.Path)), "/")
+	if o.Output == "" && o.Extract == "" {
+		if o.Path != "" {
+			o.Output = path.Base(o.Path)
+		} else {
+			o.Output = path.Base(o.project) + "-" + strings.ReplaceAll(o.Job, "/", "-") + ".zip"
+		}
+	}
+	gitlabClient, err := f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.gitlabClient = gitlabClient
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ArtifactsOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return fmt.Errorf("please enter project name and id")
+	}
+	if strings.TrimSpace(o.Job) == "" {
+		return cmdutil.UsageErrorf(cmd, "--job can not be empty")
+	}
+	if o.Extract != "" && o.Path != "" {
+		return cmdutil.UsageErrorf(cmd, "--extract can not be combined with --path, which downloads a single file")
+	}
+	if o.Output == "-" && o.Extract != "" {
+		return cmdutil.UsageErrorf(cmd, "--extract can not be used when writing the artifacts to stdout")
+	}
+	return nil
+}
+
+// Run executes a get subcommand using the specified options.
+func (o *ArtifactsOptions) Run(args []string) error {
+	job, err := o.find()
+	if err != nil {
+		return err
+	}
+	if o.Output == "-" {
+		return o.download(job, o.ioStreams.Out)
+	}
+	name := o.Output
+	if name == "" {
+		tmp, err := os.CreateTemp("", "glctl-artifacts-*.zip")
+		if err != nil {
+			return err
+		}
+		name = tmp.Name()
+		_ = tmp.Close()
+		defer os.Remove(name)
+	}
+	if err = o.save(job, name); err != nil {
+		return err
+	}
+	if o.Extract == "" {
+		_, err = fmt.Fprintf(o.ioStreams.Out, "artifacts of job %s #%d saved to %s\n", job.Name, job.ID, name)
+		return err
+	}
+	if err = os.MkdirAll(o.Extract, 0o755); err != nil {
+		return err
+	}
+	if err = archive.Unzip(name, o.Extract, nil); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.ioStreams.Out, "artifacts of job %s #%d extracted to %s\n", job.Name, job.ID, o.Extract)
+	return err
+}
+
+// find returns the job named by --job in the last successful pipeline on
+// the ref, and fails when its artifacts are gone.
+func (o *ArtifactsOptions) find() (*gitlab.Job, error) {
+	if o.Ref == "" {
+		project, _, err := o.gitlabClient.Projects.GetProject(o.project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		o.Ref = project.DefaultBranch
+	}
+	pipelines, _, err := o.gitlabClient.Pipelines.ListProjectPipelines(o.project, &gitlab.ListProjectPipelinesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 1},
+		Ref:         pointer.ToString(o.Ref),
+		Status:      gitlab.Ptr(gitlab.Success),
+		OrderBy:     pointer.ToString("id"),
+		Sort:        pointer.ToString("desc"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, fmt.Errorf("no successful pipeline on %s in project %s", o.Ref, o.project)
+	}
+	pipeline := pipelines[0]
+	var job *gitlab.Job
+	opt := &gitlab.ListJobsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+	err = cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Job, *gitlab.Response, error) {
+			return o.gitlabClient.Jobs.ListPipelineJobs(o.project, pipeline.ID, opt, options...)
+		}, func(page []*gitlab.Job) error {
+			for _, j := range page {
+				if j.Name == o.Job && (job == nil || j.ID > job.ID) {
+					job = j
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job %s not found in pipeline #%d, the last successful one on %s", o.Job, pipeline.ID, o.Ref)
+	}
+	if job.ArtifactsFile.Filename == "" {
+		if expired(job) {
+			return nil, expiredError(job)
+		}
+		return nil, fmt.Errorf("job %s #%d of pipeline #%d has no artifacts", job.Name, job.ID, pipeline.ID)
+	}
+	return job, nil
+}
+
+// save streams the artifacts into the file name and verifies them, the file
+// is removed again when either fails.
+func (o *ArtifactsOptions) save(job *gitlab.Job, name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	err = o.download(job, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && o.Path == "" {
+		if err = archive.VerifyZip(name); err != nil {
+			err = fmt.Errorf("the artifacts of job %s #%d are corrupt: %w", job.Name, job.ID, err)
+		}
+	}
+	if err != nil {
+		_ = os.Remove(name)
+	}
+	return err
+}
+
+func (o *ArtifactsOptions) download(job *gitlab.Job, w io.Writer) error {
+	u := fmt.Sprintf("projects/%s/jobs/%d/artifacts", gitlab.PathEscape(o.project), job.ID)
+	var size int64
+	if o.Path != "" {
+		segments := strings.Split(o.Path, "/")
+		for i, s := range segments {
+			segments[i] = url.PathEscape(s)
+		}
+		u += "/" + strings.Join(segments, "/")
+	} else {
+		size = job.ArtifactsFile.Size
+	}
+	req, err := o.gitlabClient.NewRequest(http.MethodGet, u, nil, []gitlab.RequestOptionFunc{cmdutil.SkipCache()})
+	if err != nil {
+		return err
+	}
+	// the progress goes to stderr so it never ends up in redirected artifacts.
+	out := io.Discard
+	if term.IsTerminal(o.ioStreams.ErrOut) {
+		out = o.ioStreams.ErrOut
+	}
+	bar := progress.NewBar(out, size, fmt.Sprintf(" downloading %s #%d", job.Name, job.ID))
+	counter := &countWriter{}
+	if _, err = o.gitlabClient.Do(req, io.MultiWriter(w, bar, counter)); err != nil {
+		if errors.Is(err, gitlab.ErrNotFound) {
+			switch {
+			case o.Path != "":
+				return fmt.Errorf("file %s not found in the artifacts of job %s #%d", o.Path, job.Name, job.ID)
+			case expired(job):
+				return expiredError(job)
+			}
+		}
+		return err
+	}
+	bar.Done()
+	if size > 0 && counter.n != size {
+		return fmt.Errorf("the download of the artifacts of job %s #%d is incomplete, got %d of %d bytes",
+			job.Name, job.ID, counter.n, size)
+	}
+	return nil
+}
+
+// expired reports whether the artifacts of the job passed their expiry date.
+func expired(job *gitlab.Job) bool {
+	return job.ArtifactsExpireAt != nil && job.ArtifactsExpireAt.Before(time.Now())
+}
+
+func expiredError(job *gitlab.Job) error {
+	return fmt.Errorf("the artifacts of job %s #%d expired on %s, run the pipeline again to recreate them",
+		job.Name, job.ID, job.ArtifactsExpireAt.Local().Format(time.DateTime))
+}
+
+// countWriter counts the bytes written to it.
+type countWriter struct {
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
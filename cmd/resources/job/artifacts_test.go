@@ -0,0 +1,88 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *ArtifactsOptions)
+		wantError   error
+	}{{
+		name: "extract a single file",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ArtifactsOptions) {
+			opt.Job = "build"
+			opt.Path = "dist/app"
+			opt.Extract = filepath.Join(dir, "dist")
+		},
+		wantError: errors.New("--extract can not be combined with --path, which downloads a single file\n" +
+			"See 'artifacts -h' for help and examples"),
+	}, {
+		name: "extract from stdout",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ArtifactsOptions) {
+			opt.Job = "build"
+			opt.Output = "-"
+			opt.Extract = filepath.Join(dir, "dist")
+		},
+		wantError: errors.New("--extract can not be used when writing the artifacts to stdout\n" +
+			"See 'artifacts -h' for help and examples"),
+	}, {
+		name: "no successful pipeline on the ref",
+		args: []string{"Group2/SubGroup3/Project13"},
+		optionsFunc: func(opt *ArtifactsOptions) {
+			opt.Job = "build"
+			opt.Ref = "no-such-branch"
+			opt.Output = filepath.Join(dir, "build.zip")
+		},
+		wantError: errors.New("no successful pipeline on no-such-branch in project Group2/SubGroup3/Project13"),
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewGetArtifactsCmd(factory, streams)
+			var cmdOptions = NewArtifactsOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, nil, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			if err != nil {
+				cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+	"github.com/huhouhua/glctl/pkg/util/term"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+var (
+	// tracePollInterval is the time between two requests for the new part
+	// of the trace of a running job.
+	tracePollInterval = 2 * time.Second
+
+	// activeStatuses are the statuses of a job whose trace may still grow.
+	activeStatuses = map[string]bool{
+		"created":              true,
+		"waiting_for_resource": true,
+		"preparing":            true,
+		"pending":              true,
+		"running":              true,
+		"scheduled":            true,
+	}
+)
+
+type LogsOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	id           int64
+	Follow       bool
+	Tail         int
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	logsJobExample = templates.Examples(`
+# print the log of a job
+glctl logs job group/myapp 81234
+
+# print the last 100 lines of the log of a job
+glctl logs job group/myapp 81234 --tail=100
+
+# stream the log of a running job until it finishes
+glctl logs job 81234 -p group/myapp -f`)
+)
+
+func NewLogsOptions(ioStreams genericiooptions.IOStreams) *LogsOptions {
+	return &LogsOptions{
+		ioStreams: ioStreams,
+		Tail:      -1,
+	}
+}
+
+func NewLogsJobCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewLogsOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "job",
+		Aliases:               []string{"jobs"},
+		Short:                 "Print the log of a job",
+		Example:               logsJobExample,
+		Args:                  jobArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *LogsOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	f := cmd.Flags()
+	f.BoolVarP(&o.Follow, "follow", "f", o.Follow, "Stream the log until the job finishes")
+	f.IntVar(&o.Tail, "tail", o.Tail, "Lines of the end of the log to print, -1 prints all of it")
+}
+
+// Complete completes all the required options.
+func (o *LogsOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.id, err = parseJob(args, o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *LogsOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Tail < -1 {
+		return cmdutil.UsageErrorf(cmd, "--tail must be -1 or greater")
+	}
+	return nil
+}
+
+// Run executes a logs subcommand using the specified options.
+func (o *LogsOptions) Run(args []string) error {
+	w := newTraceWriter(o.ioStreams.Out, !term.IsTerminal(o.ioStreams.Out))
+	trace, err := o.trace(0)
+	if err != nil {
+		return err
+	}
+	offset := len(trace)
+	if _, err = w.Write(lastLines(trace, o.Tail)); err != nil {
+		return err
+	}
+	for o.Follow {
+		job, _, err := o.gitlabClient.Jobs.GetJob(o.project, o.id, cmdutil.SkipCache())
+		if err != nil {
+			return err
+		}
+		// the job is read before the trace, so that the trace of a job which
+		// just finished is read whole.
+		if trace, err = o.trace(offset); err != nil {
+			return err
+		}
+		offset += len(trace)
+		if _, err = w.Write(trace); err != nil {
+			return err
+		}
+		if !activeStatuses[job.Status] {
+			break
+		}
+		time.Sleep(tracePollInterval)
+	}
+	return w.Flush()
+}
+
+// trace reads the trace of the job from offset. The part already read is
+// skipped by asking for a range, or by dropping it when the server ignores
+// the range and sends the whole trace.
+func (o *LogsOptions) trace(offset int) ([]byte, error) {
+	options := []gitlab.RequestOptionFunc{cmdutil.SkipCache()}
+	if offset > 0 {
+		options = append(options, gitlab.WithHeader("Range", fmt.Sprintf("bytes=%d-", offset)))
+	}
+	r, resp, err := o.gitlabClient.Jobs.GetTraceFile(o.project, o.id, options...)
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusRequestedRangeNotSatisfiable:
+			return nil, nil
+		case http.StatusPartialContent:
+			// the client takes a partial content for an error and keeps
+			// the body in it.
+			var e *gitlab.ErrorResponse
+			if errors.As(err, &e) {
+				return e.Body, nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	trace, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(trace) <= offset {
+		return nil, nil
+	}
+	return trace[offset:], nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateLogsJob(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     []string
+		wantError error
+	}{{
+		name:  "last lines",
+		flags: []string{"--tail=100", "-f"},
+	}, {
+		name:      "negative tail",
+		flags:     []string{"--tail=-2"},
+		wantError: errors.New("--tail must be -1 or greater\nSee 'job -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "job"}
+			cmdOptions := NewLogsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, cmdOptions.Validate(cmd, nil))
+		})
+	}
+}
+
+func TestRunLogsJob(t *testing.T) {
+	// the trace grows by a line on every read, the job finishes with the
+	// third one.
+	traces := []string{"one\n", "one\ntwo\n", "one\ntwo\nthree\n"}
+	tests := []struct {
+		name       string
+		follow     bool
+		tail       int
+		honorRange bool
+		want       string
+	}{{
+		name: "whole log",
+		tail: -1,
+		want: "one\n",
+	}, {
+		name:   "follow a server ignoring the range",
+		follow: true,
+		tail:   -1,
+		want:   "one\ntwo\nthree\n",
+	}, {
+		name:       "follow a server sending the range",
+		follow:     true,
+		tail:       -1,
+		honorRange: true,
+		want:       "one\ntwo\nthree\n",
+	}, {
+		name:   "follow only the new lines",
+		follow: true,
+		tail:   0,
+		want:   "two\nthree\n",
+	}}
+	defer func(interval time.Duration) { tracePollInterval = interval }(tracePollInterval)
+	tracePollInterval = time.Millisecond
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reads := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/trace") {
+					status := "running"
+					if reads >= len(traces)-1 {
+						status = "success"
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprintf(w, `{"id":81234,"status":%q}`, status)
+					return
+				}
+				trace := traces[min(reads, len(traces)-1)]
+				reads++
+				var offset int
+				if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset); err == nil && tc.honorRange {
+					if offset >= len(trace) {
+						w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+						return
+					}
+					w.WriteHeader(http.StatusPartialContent)
+					trace = trace[offset:]
+				}
+				_, _ = w.Write([]byte(trace))
+			}))
+			defer srv.Close()
+			client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			assert.NoError(t, err)
+
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			o := NewLogsOptions(streams)
+			o.gitlabClient, o.project, o.id = client, "group/myapp", 81234
+			o.Follow, o.Tail = tc.follow, tc.tail
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = o.Run(nil)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/cmd/require"
+)
+
+// jobArgs accepts a job id optionally preceded by its project.
+var jobArgs = cobra.MatchAll(require.MinimumNArgs(1), require.MaximumNArgs(2))
+
+// parseJob returns the project and the id of the job given by
+// <project> <job-id>, or by <job-id> with the project of --project.
+func parseJob(args []string, project string) (string, int64, error) {
+	value := args[len(args)-1]
+	if len(args) == 2 {
+		project = args[0]
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(value, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid job id %q", value)
+	}
+	if strings.TrimSpace(project) == "" {
+		return "", 0, fmt.Errorf("the project of job %s is missing, use <project> <job-id> or --project", value)
+	}
+	return project, id, nil
+}
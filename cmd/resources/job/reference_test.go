@@ -0,0 +1,68 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestParseJob(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		project     string
+		wantProject string
+		wantID      int64
+		wantError   error
+	}{{
+		name:        "project and id",
+		args:        []string{"group/myapp", "81234"},
+		wantProject: "group/myapp",
+		wantID:      81234,
+	}, {
+		name:        "id with --project",
+		args:        []string{"#81234"},
+		project:     "group/myapp",
+		wantProject: "group/myapp",
+		wantID:      81234,
+	}, {
+		name:        "project argument over --project",
+		args:        []string{"group/other", "81234"},
+		project:     "group/myapp",
+		wantProject: "group/other",
+		wantID:      81234,
+	}, {
+		name:      "missing project",
+		args:      []string{"81234"},
+		wantError: errors.New("the project of job 81234 is missing, use <project> <job-id> or --project"),
+	}, {
+		name:      "invalid id",
+		args:      []string{"group/myapp", "build"},
+		wantError: errors.New(`invalid job id "build"`),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			project, id, err := parseJob(tc.args, tc.project)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			assert.Equal(t, tc.wantProject, project)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+}
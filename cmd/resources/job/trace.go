@@ -0,0 +1,83 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+// controlSequence matches the ANSI escape sequences of a job trace and the
+// markers GitLab uses to fold its sections, which are noise outside of a
+// terminal.
+var controlSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|section_(?:start|end):\d+:[^\r\n]*\r`)
+
+// traceWriter writes a job trace, stripping its control sequences when
+// strip is set. Since a sequence may be split between two chunks of the
+// trace, a stripping writer only writes complete lines until Flush.
+type traceWriter struct {
+	w       io.Writer
+	strip   bool
+	pending []byte
+}
+
+func newTraceWriter(w io.Writer, strip bool) *traceWriter {
+	return &traceWriter{w: w, strip: strip}
+}
+
+func (t *traceWriter) Write(p []byte) (int, error) {
+	if !t.strip {
+		return t.w.Write(p)
+	}
+	t.pending = append(t.pending, p...)
+	i := bytes.LastIndexByte(t.pending, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	if _, err := t.w.Write(controlSequence.ReplaceAll(t.pending[:i+1], nil)); err != nil {
+		return 0, err
+	}
+	t.pending = append(t.pending[:0], t.pending[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes the last incomplete line of the trace.
+func (t *traceWriter) Flush() error {
+	if len(t.pending) == 0 {
+		return nil
+	}
+	_, err := t.w.Write(controlSequence.ReplaceAll(t.pending, nil))
+	t.pending = t.pending[:0]
+	return err
+}
+
+// lastLines returns the last n lines of trace, or all of it when n is
+// negative.
+func lastLines(trace []byte, n int) []byte {
+	if n < 0 {
+		return trace
+	}
+	if n == 0 {
+		return nil
+	}
+	i := len(bytes.TrimSuffix(trace, []byte("\n")))
+	for ; n > 0; n-- {
+		if i = bytes.LastIndexByte(trace[:i], '\n'); i < 0 {
+			return trace
+		}
+	}
+	return trace[i+1:]
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceWriter(t *testing.T) {
+	chunks := []string{
+		"\x1b[0KRunning with gitlab-runner 17.0\n",
+		"section_start:1700000000:step_script\r\x1b[0K\x1b[36;1mExecuting step_script\x1b[0;m\n$ make test\n\x1b[3",
+		"1;1mFAIL pkg/b\x1b[0m\nsection_end:1700000001:step_script\r\x1b[0K",
+		"ERROR: Job failed",
+	}
+	tests := []struct {
+		name  string
+		strip bool
+		want  string
+	}{{
+		name:  "strip",
+		strip: true,
+		want:  "Running with gitlab-runner 17.0\nExecuting step_script\n$ make test\nFAIL pkg/b\nERROR: Job failed",
+	}, {
+		name: "keep",
+		want: chunks[0] + chunks[1] + chunks[2] + chunks[3],
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := newTraceWriter(&buf, tc.strip)
+			for _, chunk := range chunks {
+				n, err := w.Write([]byte(chunk))
+				assert.NoError(t, err)
+				assert.Equal(t, len(chunk), n)
+			}
+			assert.NoError(t, w.Flush())
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	trace := []byte("one\ntwo\nthree\n")
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{name: "all", n: -1, want: "one\ntwo\nthree\n"},
+		{name: "none", n: 0, want: ""},
+		{name: "last", n: 1, want: "three\n"},
+		{name: "last two", n: 2, want: "two\nthree\n"},
+		{name: "more than the trace", n: 10, want: "one\ntwo\nthree\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, string(lastLines(trace, tc.n)))
+		})
+	}
+	assert.Equal(t, "two\nthree", string(lastLines([]byte("one\ntwo\nthree"), 2)))
+}
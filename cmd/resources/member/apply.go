@@ -0,0 +1,173 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+// defaultAccessLevel is the role of the members given without one in a file.
+const defaultAccessLevel = "developer"
+
+type ApplyOptions struct {
+	target
+	cmdutil.ApplyFlags
+	desired   []*Member
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	applyMembersExample = templates.Examples(`
+# add the members of team.yaml to a project and change their access level
+glctl apply members -f team.yaml -p group/myapp
+
+# also remove the members missing from team.yaml, after checking the changes
+glctl apply members -f team.yaml -G group --prune --dry-run
+glctl apply members -f team.yaml -G group --prune`)
+)
+
+func NewApplyOptions(ioStreams genericiooptions.IOStreams) *ApplyOptions {
+	return &ApplyOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewApplyMembersCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplyOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "members",
+		Aliases:               []string{"member"},
+		Short:                 "Make the members of a project or a group match a file",
+		Example:               applyMembersExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.target.addFlags(cmd)
+	cmdutil.AddApplyVarFlags(cmd, &o.ApplyFlags,
+		"The yaml file listing the members, in the format written by get members -o yaml", "remove the direct members")
+	registerCompletions(f, cmd)
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *ApplyOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.desired, err = cmdutil.ReadApplyFile[*Member](o.File, "member")
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ApplyOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd); err != nil {
+		return err
+	}
+	return normalize(o.desired, o.File)
+}
+
+// Run executes an apply subcommand using the specified options.
+func (o *ApplyOptions) Run(args []string) error {
+	current, err := o.list(false)
+	if err != nil {
+		return err
+	}
+	return o.applier().Run(o.ioStreams.Out, current, o.desired, o.ApplyFlags)
+}
+
+// applier applies the changes of the direct members to the target.
+func (o *ApplyOptions) applier() *cmdutil.Applier[*Member] {
+	return &cmdutil.Applier[*Member]{
+		Kind:       "member",
+		Target:     o.target.String(),
+		CreateVerb: "add",
+		DeleteVerb: "remove",
+		ID:         func(m *Member) string { return m.Username },
+		Diff:       diffMember,
+		Describe:   memberFields,
+		Create:     o.add,
+		Update:     o.update,
+		Delete:     o.remove,
+	}
+}
+
+// normalize fills in the defaults of the members read from file and rejects
+// the ones the server would refuse or that are given twice.
+func normalize(members []*Member, file string) error {
+	seen := map[string]bool{}
+	for i, m := range members {
+		if strings.TrimSpace(m.Username) == "" {
+			return fmt.Errorf("member %d of %s has no username", i+1, file)
+		}
+		if m.Inherited {
+			return fmt.Errorf("member %s of %s is inherited, it is managed in a parent group", m.Username, file)
+		}
+		if m.AccessLevel == "" {
+			m.AccessLevel = defaultAccessLevel
+		}
+		if _, err := accessLevel(m.AccessLevel); err != nil {
+			return fmt.Errorf("member %s of %s: %w", m.Username, file, err)
+		}
+		if err := validateExpiry(m.ExpiresAt); err != nil {
+			return fmt.Errorf("member %s of %s: %w", m.Username, file, err)
+		}
+		if seen[m.Username] {
+			return fmt.Errorf("member %s is given twice in %s", m.Username, file)
+		}
+		seen[m.Username] = true
+	}
+	return nil
+}
+
+// diffMember describes the changes of the access of the member old to the
+// one of m.
+func diffMember(old, m *Member) []string {
+	var fields []string
+	if old.AccessLevel != m.AccessLevel {
+		fields = append(fields, "access_level="+old.AccessLevel+"->"+m.AccessLevel)
+	}
+	// an expiry can not be removed, the members without one in the file keep
+	// theirs.
+	if m.ExpiresAt != "" && old.ExpiresAt != m.ExpiresAt {
+		fields = append(fields, "expires_at="+m.ExpiresAt)
+	}
+	return fields
+}
+
+// memberFields describes the access of a member added or removed.
+func memberFields(m *Member) []string {
+	fields := []string{"access_level=" + m.AccessLevel}
+	if m.ExpiresAt != "" {
+		fields = append(fields, "expires_at="+m.ExpiresAt)
+	}
+	return fields
+}
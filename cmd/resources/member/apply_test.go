@@ -0,0 +1,100 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *Member
+		desired *Member
+		want    []string
+	}{{
+		name:    "access level",
+		current: &Member{Username: "alice", AccessLevel: "developer"},
+		desired: &Member{Username: "alice", AccessLevel: "maintainer"},
+		want:    []string{"access_level=developer->maintainer"},
+	}, {
+		name:    "expiry kept without one in the file",
+		current: &Member{Username: "bob", AccessLevel: "maintainer", ExpiresAt: "2026-12-31"},
+		desired: &Member{Username: "bob", AccessLevel: "maintainer"},
+	}, {
+		name:    "expiry",
+		current: &Member{Username: "carol", AccessLevel: "reporter", ExpiresAt: "2026-06-30"},
+		desired: &Member{Username: "carol", AccessLevel: "reporter", ExpiresAt: "2026-09-30"},
+		want:    []string{"expires_at=2026-09-30"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, diffMember(tc.current, tc.desired))
+		})
+	}
+	assert.Equal(t, []string{"access_level=guest", "expires_at=2027-01-31"},
+		memberFields(&Member{Username: "dave", AccessLevel: "guest", ExpiresAt: "2027-01-31"}))
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		members   []*Member
+		want      []*Member
+		wantError error
+	}{{
+		name:    "defaults",
+		members: []*Member{{Username: "alice"}, {Username: "bob", AccessLevel: "owner", ExpiresAt: "2026-12-31"}},
+		want: []*Member{
+			{Username: "alice", AccessLevel: "developer"},
+			{Username: "bob", AccessLevel: "owner", ExpiresAt: "2026-12-31"},
+		},
+	}, {
+		name:      "given twice",
+		members:   []*Member{{Username: "alice"}, {Username: "alice", AccessLevel: "guest"}},
+		wantError: errors.New("member alice is given twice in team.yaml"),
+	}, {
+		name:      "missing username",
+		members:   []*Member{{Username: "alice"}, {AccessLevel: "guest"}},
+		wantError: errors.New("member 2 of team.yaml has no username"),
+	}, {
+		name:    "unknown access level",
+		members: []*Member{{Username: "alice", AccessLevel: "admin"}},
+		wantError: errors.New(`member alice of team.yaml: unknown access level "admin", ` +
+			`choose from [minimal-access, guest, planner, reporter, developer, maintainer, owner]`),
+	}, {
+		name:      "invalid expiry",
+		members:   []*Member{{Username: "alice", ExpiresAt: "tomorrow"}},
+		wantError: errors.New(`member alice of team.yaml: invalid expiry date "tomorrow", use the YYYY-MM-DD format`),
+	}, {
+		name:      "inherited",
+		members:   []*Member{{Username: "root", Inherited: true}},
+		wantError: errors.New("member root of team.yaml is inherited, it is managed in a parent group"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := normalize(tc.members, "team.yaml")
+			if tc.wantError != nil {
+				assert.EqualError(t, err, tc.wantError.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, tc.members)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	target
+	AccessLevel string
+	ExpiresAt   string
+	ioStreams   genericiooptions.IOStreams
+}
+
+var (
+	createMemberExample = templates.Examples(`
+# add two developers to a project
+glctl create member alice bob -p group/myapp
+
+# give a contractor reporter access to a group until the end of the year
+glctl create member carol -G group --access-level=reporter --expires-at=2026-12-31`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams:   ioStreams,
+		AccessLevel: "developer",
+	}
+}
+
+func NewCreateMemberCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "member",
+		Aliases:               []string{"members"},
+		Short:                 "Add users to a project or a group by their username",
+		Example:               createMemberExample,
+		Args:                  require.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"user"},
+	}
+	o.AddFlags(cmd)
+	registerCompletions(f, cmd)
+	registerAccessLevelCompletion(cmd)
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	addMemberFlags(cmd, &o.AccessLevel, &o.ExpiresAt)
+}
+
+// addMemberFlags registers the flags setting the access of a member.
+func addMemberFlags(cmd *cobra.Command, level, expires *string) {
+	f := cmd.Flags()
+	f.StringVar(level, "access-level", *level,
+		fmt.Sprintf("The role of the member (%s)", strings.Join(accessLevelNames(), ", ")))
+	f.StringVar(expires, "expires-at", *expires, "The date the membership expires on, in the YYYY-MM-DD format")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd); err != nil {
+		return err
+	}
+	for _, username := range args {
+		if strings.TrimSpace(username) == "" {
+			return cmdutil.UsageErrorf(cmd, "a username can not be empty")
+		}
+	}
+	if err := validate.ValidateFlagStringValue(accessLevelNames(), cmd, "access-level"); err != nil {
+		return err
+	}
+	return validateExpiry(o.ExpiresAt)
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	for _, username := range args {
+		m := &Member{Username: username, AccessLevel: o.AccessLevel, ExpiresAt: o.ExpiresAt}
+		if err := o.add(m); err != nil {
+			return fmt.Errorf("failed to add %s to %s: %w", username, o.target.String(), err)
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "%s added to %s as %s\n", username, o.target.String(), o.AccessLevel)
+	}
+	return nil
+}
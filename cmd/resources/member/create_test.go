@@ -0,0 +1,138 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreateMember(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createTestUser(t, client, "glctl-create-member")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "no target",
+		args:      []string{"glctl-create-member"},
+		wantError: errors.New("one of --project or --group is required\nSee 'member -h' for help and examples"),
+	}, {
+		name:      "empty username",
+		args:      []string{"glctl-create-member", " "},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("a username can not be empty\nSee 'member -h' for help and examples"),
+	}, {
+		name:  "unknown access level",
+		args:  []string{"glctl-create-member"},
+		flags: []string{"-p=" + project, "--access-level=admin"},
+		wantError: errors.New("'admin' is not a recognized value of 'access-level' flag; " +
+			"choose from [minimal-access, guest, planner, reporter, developer, maintainer, owner]"),
+	}, {
+		name:      "invalid expiry",
+		args:      []string{"glctl-create-member"},
+		flags:     []string{"-p=" + project, "--expires-at=31/12/2026"},
+		wantError: errors.New(`invalid expiry date "31/12/2026", use the YYYY-MM-DD format`),
+	}, {
+		name:    "add a reporter until the end of the year",
+		args:    []string{"glctl-create-member"},
+		flags:   []string{"-p=" + project, "--access-level=reporter", "--expires-at=2026-12-31"},
+		wantOut: "glctl-create-member added to project " + project + " as reporter\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "member"}
+			cmdOptions := NewCreateOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() {
+				if m, err := cmdOptions.find("glctl-create-member"); err == nil {
+					_ = cmdOptions.remove(m)
+				}
+			})
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+}
+
+// createTestUser creates a user, it is deleted again when the test ends.
+func createTestUser(t *testing.T, client *gitlab.Client, username string) {
+	t.Helper()
+	user, _, err := client.Users.CreateUser(&gitlab.CreateUserOptions{
+		Username:            pointer.ToString(username),
+		Name:                pointer.ToString(username),
+		Email:               pointer.ToString(username + "@example.com"),
+		ForceRandomPassword: pointer.ToBool(true),
+		SkipConfirmation:    pointer.ToBool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = client.Users.DeleteUser(user.ID)
+	})
+}
+
+// addTestMember creates a user and makes it a developer of project, both
+// are removed again when the test ends.
+func addTestMember(t *testing.T, client *gitlab.Client, project, username string) {
+	t.Helper()
+	createTestUser(t, client, username)
+	tgt := &target{client: client, project: project}
+	m := &Member{Username: username, AccessLevel: "developer"}
+	if err := tgt.add(m); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if m, err := tgt.find(username); err == nil {
+			_ = tgt.remove(m)
+		}
+	})
+}
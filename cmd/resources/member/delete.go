@@ -0,0 +1,98 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+type DeleteOptions struct {
+	target
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	deleteMemberExample = templates.Examples(`
+# remove a user from a project, the access inherited from the groups is kept
+glctl delete member alice -p group/myapp`)
+)
+
+func NewDeleteOptions(ioStreams genericiooptions.IOStreams) *DeleteOptions {
+	return &DeleteOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewDeleteMemberCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDeleteOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "member",
+		Aliases:               []string{"members"},
+		Short:                 "Remove a member from a project or a group",
+		Example:               deleteMemberExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"user"},
+	}
+	o.target.addFlags(cmd)
+	registerCompletions(f, cmd)
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *DeleteOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DeleteOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd); err != nil {
+		return err
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the username can not be empty")
+	}
+	return nil
+}
+
+// Run executes a delete subcommand using the specified options.
+func (o *DeleteOptions) Run(args []string) error {
+	m, err := o.find(args[0])
+	if err != nil {
+		return err
+	}
+	if err = o.remove(m); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "%s removed from %s\n", m.Username, o.target.String())
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestDeleteMember(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTestMember(t, client, project, "glctl-delete-member")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "no target",
+		args:      []string{"glctl-delete-member"},
+		wantError: errors.New("one of --project or --group is required\nSee 'member -h' for help and examples"),
+	}, {
+		name:      "empty username",
+		args:      []string{""},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("the username can not be empty\nSee 'member -h' for help and examples"),
+	}, {
+		name:    "remove a member",
+		args:    []string{"glctl-delete-member"},
+		flags:   []string{"-p=" + project},
+		wantOut: "glctl-delete-member removed from project " + project + "\n",
+	}, {
+		name:      "not a direct member any more",
+		args:      []string{"glctl-delete-member"},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("glctl-delete-member is not a direct member of project " + project),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "member"}
+			cmdOptions := NewDeleteOptions(streams)
+			cmdOptions.target.addFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+}
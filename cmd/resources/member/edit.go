@@ -0,0 +1,126 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type EditOptions struct {
+	target
+	AccessLevel string
+	ExpiresAt   string
+	ioStreams   genericiooptions.IOStreams
+}
+
+var (
+	editMemberExample = templates.Examples(`
+# make a developer of a project a maintainer
+glctl edit member alice -p group/myapp --access-level=maintainer
+
+# extend the membership of a contractor
+glctl edit member carol -G group --expires-at=2027-06-30`)
+)
+
+func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
+	return &EditOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewEditMemberCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewEditOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "member",
+		Aliases:               []string{"members"},
+		Short:                 "Change the access level or the expiry of a member of a project or a group",
+		Example:               editMemberExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"user"},
+	}
+	o.AddFlags(cmd)
+	registerCompletions(f, cmd)
+	registerAccessLevelCompletion(cmd)
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *EditOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	addMemberFlags(cmd, &o.AccessLevel, &o.ExpiresAt)
+}
+
+// Complete completes all the required options.
+func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd); err != nil {
+		return err
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the username can not be empty")
+	}
+	if o.AccessLevel == "" && o.ExpiresAt == "" {
+		return cmdutil.UsageErrorf(cmd, "nothing to edit, use at least one of --access-level, --expires-at")
+	}
+	if o.AccessLevel != "" {
+		if err := validate.ValidateFlagStringValue(accessLevelNames(), cmd, "access-level"); err != nil {
+			return err
+		}
+	}
+	return validateExpiry(o.ExpiresAt)
+}
+
+// Run executes an edit subcommand using the specified options.
+func (o *EditOptions) Run(args []string) error {
+	old, err := o.find(args[0])
+	if err != nil {
+		return err
+	}
+	m := *old
+	if o.AccessLevel != "" {
+		m.AccessLevel = o.AccessLevel
+	}
+	if o.ExpiresAt != "" {
+		m.ExpiresAt = o.ExpiresAt
+	}
+	if err = o.update(old, &m); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "%s is %s of %s\n", m.Username, m.AccessLevel, o.target.String())
+	return nil
+}
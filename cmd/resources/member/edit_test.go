@@ -0,0 +1,98 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestEditMember(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTestMember(t, client, project, "glctl-edit-member")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:  "nothing to edit",
+		args:  []string{"glctl-edit-member"},
+		flags: []string{"-p=" + project},
+		wantError: errors.New("nothing to edit, use at least one of --access-level, --expires-at\n" +
+			"See 'member -h' for help and examples"),
+	}, {
+		name:      "empty username",
+		args:      []string{" "},
+		flags:     []string{"-p=" + project, "--access-level=maintainer"},
+		wantError: errors.New("the username can not be empty\nSee 'member -h' for help and examples"),
+	}, {
+		name:      "not a direct member",
+		args:      []string{"glctl-no-such-member"},
+		flags:     []string{"-p=" + project, "--access-level=maintainer"},
+		wantError: errors.New("glctl-no-such-member is not a direct member of project " + project),
+	}, {
+		name:    "make a developer a maintainer",
+		args:    []string{"glctl-edit-member"},
+		flags:   []string{"-p=" + project, "--access-level=maintainer", "--expires-at=2027-06-30"},
+		wantOut: "glctl-edit-member is maintainer of project " + project + "\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "member"}
+			cmdOptions := NewEditOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+	m, err := (&target{client: client, project: project}).find("glctl-edit-member")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "maintainer", m.AccessLevel)
+	assert.Equal(t, "2027-06-30", m.ExpiresAt)
+}
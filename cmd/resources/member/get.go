@@ -0,0 +1,114 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+type ListOptions struct {
+	target
+	Out       string
+	Inherited bool
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	getMembersExample = templates.Examples(`
+# list the members of a project
+glctl get members -p group/myapp
+
+# also list the members inherited from the parent groups
+glctl get members -p group/myapp --inherited
+
+# write the members of a group in the format read by apply members
+glctl get members -G group -o yaml > team.yaml`)
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		Out:       "simple",
+	}
+}
+
+func NewGetMembersCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "members",
+		Aliases:               []string{"member"},
+		Short:                 "List the members of a project or a group",
+		Example:               getMembersExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"users"},
+	}
+	o.AddFlags(cmd)
+	registerCompletions(f, cmd)
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmd.Flags().BoolVar(&o.Inherited, "inherited", o.Inherited,
+		"If present, also list the members inherited from the parent groups.")
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	return o.target.validate(cmd)
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	members, err := o.list(o.Inherited)
+	if err != nil {
+		return err
+	}
+	printer := cmdutil.NewListPrinter(o.Out, o.ioStreams.Out,
+		[]string{"USERNAME", "NAME", "ACCESS LEVEL", "EXPIRES", "SOURCE"},
+		func(m *Member) []string {
+			source := "direct"
+			if m.Inherited {
+				source = "inherited"
+			}
+			return []string{m.Username, m.Name, m.AccessLevel, m.ExpiresAt, source}
+		})
+	if err = printer.PrintChunk(members); err != nil {
+		return err
+	}
+	return printer.Flush()
+}
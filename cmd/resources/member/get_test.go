@@ -0,0 +1,85 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetMembers(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTestMember(t, client, project, "glctl-get-member")
+	tests := []struct {
+		name      string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "both targets",
+		flags:     []string{"-p=" + project, "-G=Group2"},
+		wantError: errors.New("only one of --project and --group can be used\nSee 'members -h' for help and examples"),
+	}, {
+		name:    "list the members of a project",
+		flags:   []string{"-p=" + project},
+		wantOut: "glctl-get-member",
+	}, {
+		name:    "list the inherited members as yaml",
+		flags:   []string{"-p=" + project, "--inherited", "-o=yaml"},
+		wantOut: "inherited: true",
+	}, {
+		name:  "list the members of a group",
+		flags: []string{"-G=Group2", "-o=json"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "members"}
+			cmdOptions := NewListOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(nil)
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, out, tc.wantOut)
+		})
+	}
+}
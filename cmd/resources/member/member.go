@@ -0,0 +1,291 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// accessLevels are the roles of a member, from the least to the most
+// privileged.
+var accessLevels = []struct {
+	name  string
+	value gitlab.AccessLevelValue
+}{
+	{"minimal-access", gitlab.MinimalAccessPermissions},
+	{"guest", gitlab.GuestPermissions},
+	{"planner", gitlab.PlannerPermissions},
+	{"reporter", gitlab.ReporterPermissions},
+	{"developer", gitlab.DeveloperPermissions},
+	{"maintainer", gitlab.MaintainerPermissions},
+	{"owner", gitlab.OwnerPermissions},
+}
+
+// Member is a user of a project or a group, in the form members are printed
+// and applied.
+type Member struct {
+	Username    string `json:"username" yaml:"username"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	AccessLevel string `json:"access_level" yaml:"access_level"`
+	ExpiresAt   string `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+
+	// Inherited is set for the members of a parent group, which are managed
+	// in that group.
+	Inherited bool `json:"inherited,omitempty" yaml:"inherited,omitempty"`
+
+	id int64
+}
+
+// target is the project or group members are managed in.
+type target struct {
+	client  *gitlab.Client
+	project string
+	group   string
+}
+
+// addFlags registers the flags choosing the target.
+func (t *target) addFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &t.project)
+	cmdutil.AddFromGroupVarPFlag(cmd, &t.group)
+}
+
+// validate makes sure exactly one target is given.
+func (t *target) validate(cmd *cobra.Command) error {
+	switch {
+	case t.project == "" && t.group == "":
+		return cmdutil.UsageErrorf(cmd, "one of --project or --group is required")
+	case t.project != "" && t.group != "":
+		return cmdutil.UsageErrorf(cmd, "only one of --project and --group can be used")
+	}
+	return nil
+}
+
+func (t *target) String() string {
+	if t.project != "" {
+		return "project " + t.project
+	}
+	return "group " + t.group
+}
+
+// list returns the members of the target ordered by username, with
+// inherited the members of the parent groups are included.
+func (t *target) list(inherited bool) ([]*Member, error) {
+	direct, err := t.listMembers(false)
+	if err != nil || !inherited {
+		return direct, err
+	}
+	all, err := t.listMembers(true)
+	if err != nil {
+		return nil, err
+	}
+	isDirect := map[int64]bool{}
+	for _, m := range direct {
+		isDirect[m.id] = true
+	}
+	for _, m := range all {
+		m.Inherited = !isDirect[m.id]
+	}
+	return all, nil
+}
+
+func (t *target) listMembers(all bool) ([]*Member, error) {
+	var members []*Member
+	chunk := gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}
+	var err error
+	if t.project != "" {
+		opt := &gitlab.ListProjectMembersOptions{ListOptions: chunk}
+		list := t.client.ProjectMembers.ListProjectMembers
+		if all {
+			list = t.client.ProjectMembers.ListAllProjectMembers
+		}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectMember, *gitlab.Response, error) {
+				return list(t.project, opt, options...)
+			}, func(page []*gitlab.ProjectMember) error {
+				for _, m := range page {
+					members = append(members, newMember(m.ID, m.Username, m.Name, m.AccessLevel, m.ExpiresAt))
+				}
+				return nil
+			})
+	} else {
+		opt := &gitlab.ListGroupMembersOptions{ListOptions: chunk}
+		list := t.client.Groups.ListGroupMembers
+		if all {
+			list = t.client.Groups.ListAllGroupMembers
+		}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.GroupMember, *gitlab.Response, error) {
+				return list(t.group, opt, options...)
+			}, func(page []*gitlab.GroupMember) error {
+				for _, m := range page {
+					members = append(members, newMember(m.ID, m.Username, m.Name, m.AccessLevel, m.ExpiresAt))
+				}
+				return nil
+			})
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Username < members[j].Username })
+	return members, err
+}
+
+// add makes the user named after m a member of the target.
+func (t *target) add(m *Member) error {
+	level, err := accessLevel(m.AccessLevel)
+	if err != nil {
+		return err
+	}
+	var expires *string
+	if m.ExpiresAt != "" {
+		expires = pointer.ToString(m.ExpiresAt)
+	}
+	if t.project != "" {
+		_, _, err = t.client.ProjectMembers.AddProjectMember(t.project, &gitlab.AddProjectMemberOptions{
+			Username:    pointer.ToString(m.Username),
+			AccessLevel: pointer.To(level),
+			ExpiresAt:   expires,
+		})
+		return err
+	}
+	_, _, err = t.client.GroupMembers.AddGroupMember(t.group, &gitlab.AddGroupMemberOptions{
+		Username:    pointer.ToString(m.Username),
+		AccessLevel: pointer.To(level),
+		ExpiresAt:   expires,
+	})
+	return err
+}
+
+// update sets the access level and the expiry of the member old to the ones
+// of m, an expiry can not be removed.
+func (t *target) update(old, m *Member) error {
+	level, err := accessLevel(m.AccessLevel)
+	if err != nil {
+		return err
+	}
+	var expires *string
+	if m.ExpiresAt != "" {
+		expires = pointer.ToString(m.ExpiresAt)
+	}
+	if t.project != "" {
+		_, _, err = t.client.ProjectMembers.EditProjectMember(t.project, old.id, &gitlab.EditProjectMemberOptions{
+			AccessLevel: pointer.To(level),
+			ExpiresAt:   expires,
+		})
+		return err
+	}
+	_, _, err = t.client.GroupMembers.EditGroupMember(t.group, old.id, &gitlab.EditGroupMemberOptions{
+		AccessLevel: pointer.To(level),
+		ExpiresAt:   expires,
+	})
+	return err
+}
+
+// remove removes the member m from the target.
+func (t *target) remove(m *Member) error {
+	var err error
+	if t.project != "" {
+		_, err = t.client.ProjectMembers.DeleteProjectMember(t.project, m.id)
+	} else {
+		_, err = t.client.GroupMembers.RemoveGroupMember(t.group, m.id, nil)
+	}
+	return err
+}
+
+// find returns the direct member with the username.
+func (t *target) find(username string) (*Member, error) {
+	members, err := t.list(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.Username == username {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a direct member of %s", username, t)
+}
+
+func newMember(id int64, username, name string, level gitlab.AccessLevelValue, expires *gitlab.ISOTime) *Member {
+	m := &Member{
+		Username:    username,
+		Name:        name,
+		AccessLevel: accessLevelName(level),
+		id:          id,
+	}
+	if expires != nil {
+		m.ExpiresAt = expires.String()
+	}
+	return m
+}
+
+// accessLevel returns the access level of a role.
+func accessLevel(name string) (gitlab.AccessLevelValue, error) {
+	for _, l := range accessLevels {
+		if l.name == name {
+			return l.value, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown access level %q, choose from [%s]", name, strings.Join(accessLevelNames(), ", "))
+}
+
+// accessLevelName returns the role of an access level, or its number for
+// a level glctl does not know.
+func accessLevelName(level gitlab.AccessLevelValue) string {
+	for _, l := range accessLevels {
+		if l.value == level {
+			return l.name
+		}
+	}
+	return fmt.Sprint(int64(level))
+}
+
+func accessLevelNames() []string {
+	names := make([]string, 0, len(accessLevels))
+	for _, l := range accessLevels {
+		names = append(names, l.name)
+	}
+	return names
+}
+
+// validateExpiry checks an expiry date is in the YYYY-MM-DD format.
+func validateExpiry(date string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return fmt.Errorf("invalid expiry date %q, use the YYYY-MM-DD format", date)
+	}
+	return nil
+}
+
+// registerCompletions completes the flags choosing the target.
+func registerCompletions(f cmdutil.Factory, cmd *cobra.Command) {
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("group", completion.GroupCompletionFunc(f)))
+}
+
+// registerAccessLevelCompletion completes the --access-level flag.
+func registerAccessLevelCompletion(cmd *cobra.Command) {
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("access-level",
+		cobra.FixedCompletions(accessLevelNames(), cobra.ShellCompDirectiveNoFileComp)))
+}
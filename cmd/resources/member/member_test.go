@@ -0,0 +1,79 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    target
+		wantError error
+	}{{
+		name:   "project",
+		target: target{project: "group/myapp"},
+	}, {
+		name:   "group",
+		target: target{group: "group"},
+	}, {
+		name:      "none",
+		wantError: errors.New("one of --project or --group is required\nSee 'members -h' for help and examples"),
+	}, {
+		name:      "both",
+		target:    target{project: "group/myapp", group: "group"},
+		wantError: errors.New("only one of --project and --group can be used\nSee 'members -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.target.validate(&cobra.Command{Use: "members"})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccessLevel(t *testing.T) {
+	for _, name := range accessLevelNames() {
+		level, err := accessLevel(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, accessLevelName(level))
+	}
+	level, err := accessLevel("maintainer")
+	assert.NoError(t, err)
+	assert.Equal(t, gitlab.MaintainerPermissions, level)
+
+	_, err = accessLevel("admin")
+	assert.EqualError(t, err, `unknown access level "admin", choose from `+
+		`[minimal-access, guest, planner, reporter, developer, maintainer, owner]`)
+	assert.Equal(t, "60", accessLevelName(gitlab.AdminPermissions))
+}
+
+func TestValidateExpiry(t *testing.T) {
+	assert.NoError(t, validateExpiry(""))
+	assert.NoError(t, validateExpiry("2026-12-31"))
+	assert.EqualError(t, validateExpiry("31/12/2026"), `invalid expiry date "31/12/2026", use the YYYY-MM-DD format`)
+	assert.EqualError(t, validateExpiry("2026-02-30"), `invalid expiry date "2026-02-30", use the YYYY-MM-DD format`)
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type ApproveOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	SHA          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	approveMergeRequestExample = templates.Examples(`
+# approve a merge request
+glctl approve mergerequest group/myapp!12
+
+# approve a merge request only if its head is still the reviewed commit
+glctl approve mr 12 -p group/myapp --sha=4d8f2c1e`)
+)
+
+func NewApproveOptions(ioStreams genericiooptions.IOStreams) *ApproveOptions {
+	return &ApproveOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewApproveMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApproveOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Approve a merge request",
+		Example:               approveMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ApproveOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmd.Flags().StringVar(&o.SHA, "sha", o.SHA,
+		"The head commit of the merge request, the approval fails if the merge request has changed since")
+}
+
+// Complete completes all the required options.
+func (o *ApproveOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ApproveOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Run executes an approve subcommand using the specified options.
+func (o *ApproveOptions) Run(args []string) error {
+	opt := &gitlab.ApproveMergeRequestOptions{}
+	if o.SHA != "" {
+		opt.SHA = pointer.ToString(o.SHA)
+	}
+	approvals, _, err := o.gitlabClient.MergeRequestApprovals.ApproveMergeRequest(o.project, o.iid, opt)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s!%d approved", o.project, o.iid)
+	if approvals.ApprovalsLeft > 0 {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, ", %d more %s required", approvals.ApprovalsLeft,
+			plural(approvals.ApprovalsLeft, "approval", "approvals"))
+	}
+	_, _ = fmt.Fprintln(o.ioStreams.Out)
+	return nil
+}
+
+func plural(n int64, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestApproveMergeRequest(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := createTestMergeRequest(t, client, project, "glctl-approve-mr")
+	tests := []struct {
+		name  string
+		flags []string
+		run   func(opt *ApproveOptions, args []string) error
+	}{{
+		name:  "approve a changed merge request",
+		flags: []string{"--sha=0000000000000000000000000000000000000000"},
+		run: func(opt *ApproveOptions, args []string) error {
+			err := opt.Run(args)
+			var repoErr *gitlab.ErrorResponse
+			assert.ErrorAs(t, err, &repoErr)
+			return nil
+		},
+	}, {
+		name:  "approve the reviewed head",
+		flags: []string{"--sha=" + mr.SHA},
+		run: func(opt *ApproveOptions, args []string) error {
+			var err error
+			out := cmdtesting.RunForStdout(opt.ioStreams, func() {
+				err = opt.Run(args)
+			})
+			assert.Contains(t, out, "approved")
+			return err
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewApproveOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			args := []string{mr.References.Full}
+			if err := cmdOptions.Complete(factory, cmd, args); err != nil {
+				t.Fatal(err)
+			}
+			if err := cmdOptions.Validate(cmd, args); err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, tc.run(cmdOptions, args))
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/diff"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// errLineFound stops walking the diff once the commented line is found.
+var errLineFound = errors.New("line found")
+
+type CommentOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	body         string
+	Message      string
+	File         string
+	Path         string
+	Line         int64
+	OldLine      int64
+	ReplyTo      string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	commentMergeRequestExample = templates.Examples(`
+# comment on a merge request
+glctl comment mergerequest group/myapp!12 -m "Looks good to me"
+
+# start a thread on line 42 of a changed file
+glctl comment mr group/myapp!12 --path=cmd/main.go --line=42 -m "This can be nil"
+
+# start a thread on a removed line, numbered in the old file
+glctl comment mr group/myapp!12 --path=cmd/main.go --old-line=40 -m "Why is this gone?"
+
+# reply to a thread with the content of a file, or of stdin with -
+glctl comment mr 12 -p group/myapp --reply-to=6a9c1750b37d513a43987b574953fceb50b03ce7 -F reply.md`)
+)
+
+func NewCommentOptions(ioStreams genericiooptions.IOStreams) *CommentOptions {
+	return &CommentOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewCommentMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCommentOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Comment on a merge request, on a line of its changes or in reply to a thread",
+		Example:               commentMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CommentOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	f := cmd.Flags()
+	f.StringVarP(&o.Message, "message", "m", o.Message, "The text of the comment")
+	f.StringVarP(&o.File, "file", "F", o.File, "Read the text of the comment from a file, - to read it from stdin")
+	f.StringVar(&o.Path, "path", o.Path, "Comment on a line of this changed file")
+	f.Int64Var(&o.Line, "line", o.Line, "The line of --path to comment on, numbered in the new file")
+	f.Int64Var(&o.OldLine, "old-line", o.OldLine,
+		"The removed line of --path to comment on, numbered in the old file")
+	f.StringVar(&o.ReplyTo, "reply-to", o.ReplyTo, "The id of the thread to reply to")
+}
+
+// Complete completes all the required options.
+func (o *CommentOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	if err != nil {
+		return err
+	}
+	switch o.File {
+	case "":
+		o.body = o.Message
+	case "-":
+		var content []byte
+		if content, err = io.ReadAll(o.ioStreams.In); err != nil {
+			return err
+		}
+		o.body = string(content)
+	default:
+		var content []byte
+		if content, err = cmdutil.ReadFile(o.File); err != nil {
+			return err
+		}
+		o.body = string(content)
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CommentOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Message != "" && o.File != "" {
+		return cmdutil.UsageErrorf(cmd, "--message and --file can not be combined")
+	}
+	if strings.TrimSpace(o.body) == "" {
+		return cmdutil.UsageErrorf(cmd, "the comment is empty, use --message or --file")
+	}
+	if o.Path == "" {
+		if o.Line != 0 || o.OldLine != 0 {
+			return cmdutil.UsageErrorf(cmd, "--line and --old-line require --path")
+		}
+		return nil
+	}
+	if o.ReplyTo != "" {
+		return cmdutil.UsageErrorf(cmd, "--reply-to can not be combined with --path")
+	}
+	if (o.Line > 0) == (o.OldLine > 0) {
+		return cmdutil.UsageErrorf(cmd, "--path requires exactly one of --line and --old-line")
+	}
+	return nil
+}
+
+// Run executes a comment subcommand using the specified options.
+func (o *CommentOptions) Run(args []string) error {
+	ref := fmt.Sprintf("%s!%d", o.project, o.iid)
+	switch {
+	case o.ReplyTo != "":
+		note, _, err := o.gitlabClient.Discussions.AddMergeRequestDiscussionNote(o.project, o.iid, o.ReplyTo,
+			&gitlab.AddMergeRequestDiscussionNoteOptions{Body: pointer.ToString(o.body)})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "reply %d added to thread %s of merge request %s\n", note.ID, o.ReplyTo, ref)
+	case o.Path != "":
+		position, err := o.position()
+		if err != nil {
+			return err
+		}
+		discussion, _, err := o.gitlabClient.Discussions.CreateMergeRequestDiscussion(o.project, o.iid,
+			&gitlab.CreateMergeRequestDiscussionOptions{Body: pointer.ToString(o.body), Position: position})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "thread %s started on %s of merge request %s\n",
+			discussion.ID, linePosition{path: o.Path, old: o.OldLine > 0, line: max(o.Line, o.OldLine)}, ref)
+	default:
+		note, _, err := o.gitlabClient.Notes.CreateMergeRequestNote(o.project, o.iid,
+			&gitlab.CreateMergeRequestNoteOptions{Body: pointer.ToString(o.body)})
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "comment %d added to merge request %s\n", note.ID, ref)
+	}
+	return nil
+}
+
+// position returns the position of the commented line in the latest
+// changes of the merge request. Lines of the diff are numbered in the old
+// file, the new file or both, the server needs the numbers of every side
+// the line belongs to.
+func (o *CommentOptions) position() (*gitlab.PositionOptions, error) {
+	mr, _, err := o.gitlabClient.MergeRequests.GetMergeRequest(o.project, o.iid, &gitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := listDiffs(o.gitlabClient, o.project, o.iid)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range diffs {
+		if d.NewPath != o.Path && d.OldPath != o.Path {
+			continue
+		}
+		oldLine, newLine, found := findLine(d.Diff, o.Line, o.OldLine)
+		if !found {
+			break
+		}
+		position := &gitlab.PositionOptions{
+			BaseSHA:      pointer.ToString(mr.DiffRefs.BaseSha),
+			StartSHA:     pointer.ToString(mr.DiffRefs.StartSha),
+			HeadSHA:      pointer.ToString(mr.DiffRefs.HeadSha),
+			PositionType: pointer.ToString("text"),
+			OldPath:      pointer.ToString(d.OldPath),
+			NewPath:      pointer.ToString(d.NewPath),
+		}
+		if oldLine > 0 {
+			position.OldLine = pointer.To(oldLine)
+		}
+		if newLine > 0 {
+			position.NewLine = pointer.To(newLine)
+		}
+		return position, nil
+	}
+	return nil, fmt.Errorf("%s is not part of the changes of merge request %s!%d",
+		linePosition{path: o.Path, old: o.OldLine > 0, line: max(o.Line, o.OldLine)}, o.project, o.iid)
+}
+
+// findLine looks for the line numbered newLine in the new file, or oldLine
+// in the old one, among the lines of the hunks and returns both its numbers.
+func findLine(text string, newLine, oldLine int64) (int64, int64, bool) {
+	var foundOld, foundNew int64
+	err := diff.Walk(text, func(line string, o, n int64) error {
+		if (newLine > 0 && n == newLine) || (oldLine > 0 && o == oldLine) {
+			foundOld, foundNew = o, n
+			return errLineFound
+		}
+		return nil
+	})
+	return foundOld, foundNew, errors.Is(err, errLineFound)
+}
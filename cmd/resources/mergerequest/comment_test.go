@@ -0,0 +1,108 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		optionsFunc func(opt *CommentOptions)
+		wantError   error
+	}{{
+		name: "comment",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body = "hi"
+		},
+	}, {
+		name: "message and file",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.Message, opt.File, opt.body = "hi", "reply.md", "hi"
+		},
+		wantError: errors.New("--message and --file can not be combined\nSee 'comment -h' for help and examples"),
+	}, {
+		name: "empty comment",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body = " \n"
+		},
+		wantError: errors.New("the comment is empty, use --message or --file\nSee 'comment -h' for help and examples"),
+	}, {
+		name: "line without path",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body, opt.Line = "hi", 3
+		},
+		wantError: errors.New("--line and --old-line require --path\nSee 'comment -h' for help and examples"),
+	}, {
+		name: "reply on a line",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body, opt.Path, opt.Line, opt.ReplyTo = "hi", "app.yaml", 3, "abc"
+		},
+		wantError: errors.New("--reply-to can not be combined with --path\nSee 'comment -h' for help and examples"),
+	}, {
+		name: "path with both lines",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body, opt.Path, opt.Line, opt.OldLine = "hi", "app.yaml", 3, 2
+		},
+		wantError: errors.New("--path requires exactly one of --line and --old-line\nSee 'comment -h' for help and examples"),
+	}, {
+		name: "line comment",
+		optionsFunc: func(opt *CommentOptions) {
+			opt.body, opt.Path, opt.OldLine = "hi", "app.yaml", 2
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := NewCommentOptions(genericiooptions.NewTestIOStreamsDiscard())
+			tc.optionsFunc(opt)
+			err := opt.Validate(&cobra.Command{Use: "comment"}, []string{"Group2/SubGroup3/Project13!1"})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+func TestFindLine(t *testing.T) {
+	text := "@@ -1,3 +1,3 @@\n name: app\n-image: app:v1\n+image: app:v2\n port: 80\n"
+	tests := []struct {
+		name      string
+		newLine   int64
+		oldLine   int64
+		wantOld   int64
+		wantNew   int64
+		wantFound bool
+	}{
+		{name: "added line", newLine: 2, wantNew: 2, wantFound: true},
+		{name: "removed line", oldLine: 2, wantOld: 2, wantFound: true},
+		{name: "context line", newLine: 3, wantOld: 3, wantNew: 3, wantFound: true},
+		{name: "line outside the hunks", newLine: 30},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLine, newLine, found := findLine(text, tc.newLine, tc.oldLine)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.wantOld, oldLine)
+			assert.Equal(t, tc.wantNew, newLine)
+		})
+	}
+}
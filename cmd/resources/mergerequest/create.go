@@ -0,0 +1,183 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	gitlabClient *gitlab.Client
+	mergeRequest *gitlab.CreateMergeRequestOptions
+	project      string
+	Labels       []string
+	Assignees    []string
+	Reviewers    []string
+	Draft        bool
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	createMergeRequestExample = templates.Examples(`
+# open a merge request from the feature branch into the default branch
+glctl create mergerequest --project=group/myapp --source-branch=feature --title="Add feature"
+
+# open a draft merge request into develop, assigned to jdoe and reviewed by asmith
+glctl create mr -p group/myapp --source-branch=feature --target-branch=develop --title="Add feature" \
+  --assignees=jdoe --reviewers=asmith --labels=backend --draft
+
+# squash the commits and remove the source branch once merged
+glctl create mr -p group/myapp --source-branch=fix --title="Fix login" --squash --remove-source-branch`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams: ioStreams,
+		mergeRequest: &gitlab.CreateMergeRequestOptions{
+			Title:              pointer.ToString(""),
+			Description:        pointer.ToString(""),
+			SourceBranch:       pointer.ToString(""),
+			TargetBranch:       pointer.ToString(""),
+			RemoveSourceBranch: pointer.ToBool(false),
+			Squash:             pointer.ToBool(false),
+		},
+		Out: "simple",
+	}
+}
+
+func NewCreateMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Open a merge request from a source branch into a target branch",
+		Example:               createMergeRequestExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("source-branch", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("target-branch", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVar(o.mergeRequest.SourceBranch, "source-branch", *o.mergeRequest.SourceBranch,
+		"The branch the changes are merged from")
+	validate.VerifyMarkFlagRequired(cmd, "source-branch")
+	f.StringVar(o.mergeRequest.TargetBranch, "target-branch", *o.mergeRequest.TargetBranch,
+		"The branch the changes are merged into, the default branch of the project if empty")
+	f.StringVar(o.mergeRequest.Title, "title", *o.mergeRequest.Title, "The title of the merge request")
+	validate.VerifyMarkFlagRequired(cmd, "title")
+	f.StringVar(o.mergeRequest.Description, "desc", *o.mergeRequest.Description, "The description of the merge request")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Comma-separated label names of the merge request")
+	f.StringSliceVar(&o.Assignees, "assignees", o.Assignees, "Comma-separated usernames the merge request is assigned to")
+	f.StringSliceVar(&o.Reviewers, "reviewers", o.Reviewers, "Comma-separated usernames of the reviewers of the merge request")
+	f.BoolVar(o.mergeRequest.RemoveSourceBranch, "remove-source-branch", *o.mergeRequest.RemoveSourceBranch,
+		"Remove the source branch when the merge request is merged")
+	f.BoolVar(o.mergeRequest.Squash, "squash", *o.mergeRequest.Squash,
+		"Squash the commits into a single commit when the merge request is merged")
+	f.BoolVar(&o.Draft, "draft", o.Draft, "Mark the merge request as a draft")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if len(o.Labels) > 0 {
+		o.mergeRequest.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+	}
+	if o.Draft && !strings.HasPrefix(*o.mergeRequest.Title, "Draft:") {
+		o.mergeRequest.Title = pointer.ToString("Draft: " + *o.mergeRequest.Title)
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if strings.TrimSpace(*o.mergeRequest.SourceBranch) == "" {
+		return cmdutil.UsageErrorf(cmd, "--source-branch can not be empty")
+	}
+	if strings.TrimSpace(*o.mergeRequest.Title) == "" {
+		return cmdutil.UsageErrorf(cmd, "--title can not be empty")
+	}
+	if *o.mergeRequest.SourceBranch == *o.mergeRequest.TargetBranch {
+		return cmdutil.UsageErrorf(cmd, "--source-branch and --target-branch must be different")
+	}
+	return nil
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	if *o.mergeRequest.TargetBranch == "" {
+		project, _, err := o.gitlabClient.Projects.GetProject(o.project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return err
+		}
+		o.mergeRequest.TargetBranch = pointer.ToString(project.DefaultBranch)
+	}
+	var err error
+	if len(o.Assignees) > 0 {
+		var ids []int64
+		if ids, err = cmdutil.UserIDs(o.gitlabClient, o.Assignees); err != nil {
+			return err
+		}
+		o.mergeRequest.AssigneeIDs = pointer.To(ids)
+	}
+	if len(o.Reviewers) > 0 {
+		var ids []int64
+		if ids, err = cmdutil.UserIDs(o.gitlabClient, o.Reviewers); err != nil {
+			return err
+		}
+		o.mergeRequest.ReviewerIDs = pointer.To(ids)
+	}
+	mr, _, err := o.gitlabClient.MergeRequests.CreateMergeRequest(o.project, o.mergeRequest)
+	if err != nil {
+		return err
+	}
+	return cmdutil.PrintMergeRequestsOut(o.Out, o.ioStreams.Out, &mr.BasicMergeRequest)
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreateMergeRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		optionsFunc func(opt *CreateOptions)
+		run         func(opt *CreateOptions, args []string) error
+		wantError   error
+	}{{
+		name: "empty source branch",
+		optionsFunc: func(opt *CreateOptions) {
+			opt.project = "Group2/SubGroup3/Project13"
+			opt.mergeRequest.Title = pointer.ToString("update test")
+		},
+		wantError: errors.New("--source-branch can not be empty\n" +
+			"See 'mergerequest -h' for help and examples"),
+	}, {
+		name: "same source and target branch",
+		optionsFunc: func(opt *CreateOptions) {
+			opt.project = "Group2/SubGroup3/Project13"
+			opt.mergeRequest.Title = pointer.ToString("update test")
+			opt.mergeRequest.SourceBranch = pointer.ToString("main")
+			opt.mergeRequest.TargetBranch = pointer.ToString("main")
+		},
+		wantError: errors.New("--source-branch and --target-branch must be different\n" +
+			"See 'mergerequest -h' for help and examples"),
+	}, {
+		name: "create a draft merge request into the default branch",
+		optionsFunc: func(opt *CreateOptions) {
+			opt.project = "Group2/SubGroup3/Project13"
+			opt.mergeRequest.Title = pointer.ToString("update test")
+			opt.mergeRequest.SourceBranch = pointer.ToString("glctl-create-mr")
+			opt.Labels = []string{"test"}
+			opt.Draft = true
+		},
+		run: func(opt *CreateOptions, args []string) error {
+			_, _, err := opt.gitlabClient.Branches.CreateBranch(opt.project, &gitlab.CreateBranchOptions{
+				Branch: opt.mergeRequest.SourceBranch,
+				Ref:    pointer.ToString("main"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_, _ = opt.gitlabClient.Branches.DeleteBranch(opt.project, *opt.mergeRequest.SourceBranch)
+			}()
+			out := cmdtesting.RunForStdout(opt.ioStreams, func() {
+				err = opt.Run(args)
+			})
+			assert.Contains(t, out, "Draft: update test")
+			return err
+		},
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	streams := genericiooptions.NewTestIOStreamsForPipe()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewCreateOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.run != nil {
+				err = tc.run(cmdOptions, nil)
+			} else {
+				err = cmdOptions.Run(nil)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type DeleteOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	deleteMergeRequestExample = templates.Examples(`
+# delete a merge request
+glctl delete mergerequest group/myapp!12
+
+# delete a merge request of the project given with --project
+glctl delete mr 12 --project=group/myapp`)
+)
+
+func NewDeleteOptions(ioStreams genericiooptions.IOStreams) *DeleteOptions {
+	return &DeleteOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewDeleteMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDeleteOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Delete a merge request, only administrators and project owners can delete them",
+		Example:               deleteMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *DeleteOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DeleteOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Run executes a delete subcommand using the specified options.
+func (o *DeleteOptions) Run(args []string) error {
+	_, err := o.gitlabClient.MergeRequests.DeleteMergeRequest(o.project, o.iid)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s!%d has been deleted\n", o.project, o.iid)
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestDeleteMergeRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *DeleteOptions)
+		run         func(opt *DeleteOptions, args []string) error
+		wantError   error
+	}{{
+		name:      "missing project",
+		args:      []string{"!1"},
+		wantError: errors.New(`the project of merge request "!1" is missing, use <project>!<iid> or --project`),
+	}, {
+		name: "merge request not found",
+		args: []string{"100000"},
+		optionsFunc: func(opt *DeleteOptions) {
+			opt.project = "Group2/SubGroup3/Project13"
+		},
+		run: func(opt *DeleteOptions, args []string) error {
+			err := opt.Run(args)
+			assert.ErrorIs(t, err, gitlab.ErrNotFound)
+			return nil
+		},
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewDeleteOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.run != nil {
+				err = tc.run(cmdOptions, tc.args)
+			} else {
+				err = cmdOptions.Run(tc.args)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
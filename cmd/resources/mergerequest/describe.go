@@ -0,0 +1,255 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type DescribeOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	describeMergeRequestExample = templates.Examples(`
+# show the details, the pipeline and the approvals of a merge request
+glctl describe mergerequest group/myapp!12
+
+# describe a merge request of the project given with --project
+glctl describe mr 12 --project=group/myapp`)
+)
+
+func NewDescribeOptions(ioStreams genericiooptions.IOStreams) *DescribeOptions {
+	return &DescribeOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewDescribeMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDescribeOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Show the details, the pipeline and the approvals of a merge request",
+		Example:               describeMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *DescribeOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DescribeOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Run executes a describe subcommand using the specified options.
+func (o *DescribeOptions) Run(args []string) error {
+	mr, _, err := o.gitlabClient.MergeRequests.GetMergeRequest(o.project, o.iid, &gitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return err
+	}
+	approvals, _, err := o.gitlabClient.MergeRequests.GetMergeRequestApprovals(o.project, o.iid)
+	if err != nil {
+		return err
+	}
+	discussions, err := listDiscussions(o.gitlabClient, o.project, o.iid)
+	if err != nil {
+		return err
+	}
+	var jobs []*gitlab.Job
+	if mr.HeadPipeline != nil {
+		opt := &gitlab.ListJobsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Job, *gitlab.Response, error) {
+				return o.gitlabClient.Jobs.ListPipelineJobs(o.project, mr.HeadPipeline.ID, opt, options...)
+			}, func(page []*gitlab.Job) error {
+				jobs = append(jobs, page...)
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return describe(o.ioStreams.Out, o.project, mr, approvals, discussions, jobs)
+}
+
+// describe writes the details of the merge request as aligned fields,
+// followed by the jobs of its pipeline and its description.
+func describe(
+	out io.Writer,
+	pid string,
+	mr *gitlab.MergeRequest,
+	approvals *gitlab.MergeRequestApprovals,
+	discussions []*gitlab.Discussion,
+	jobs []*gitlab.Job,
+) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	field := func(name, value string) {
+		_, _ = fmt.Fprintf(w, "%s:\t%s\n", name, value)
+	}
+	field("Name", displayReference(pid, &mr.BasicMergeRequest))
+	field("Title", mr.Title)
+	state := mr.State
+	if mr.Draft {
+		state += " (draft)"
+	}
+	field("State", state)
+	field("Author", usernames(mr.Author))
+	field("Assignees", usernames(mr.Assignees...))
+	field("Reviewers", usernames(mr.Reviewers...))
+	field("Branches", mr.SourceBranch+" -> "+mr.TargetBranch)
+	field("Labels", orNone(strings.Join(mr.Labels, ", ")))
+	milestone := ""
+	if mr.Milestone != nil {
+		milestone = mr.Milestone.Title
+	}
+	field("Milestone", orNone(milestone))
+	field("Created", formatTime(mr.CreatedAt))
+	field("Updated", formatTime(mr.UpdatedAt))
+	if mr.MergedAt != nil {
+		field("Merged", fmt.Sprintf("%s by %s", formatTime(mr.MergedAt), usernames(mr.MergedBy)))
+	}
+	if mr.ClosedAt != nil && mr.State == "closed" {
+		field("Closed", fmt.Sprintf("%s by %s", formatTime(mr.ClosedAt), usernames(mr.ClosedBy)))
+	}
+	if mr.State == "opened" {
+		status := strings.ReplaceAll(mr.DetailedMergeStatus, "_", " ")
+		if mr.HasConflicts {
+			status += ", has conflicts"
+		}
+		field("Merge Status", status)
+	}
+	field("Approvals", describeApprovals(approvals))
+	resolvable, open := unresolved(discussions)
+	field("Threads", fmt.Sprintf("%d unresolved of %d", open, resolvable))
+	field("URL", mr.WebURL)
+	if mr.HeadPipeline == nil {
+		field("Pipeline", "<none>")
+	} else {
+		field("Pipeline", fmt.Sprintf("#%d %s  %s", mr.HeadPipeline.ID, mr.HeadPipeline.Status, mr.HeadPipeline.WebURL))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(jobs) > 0 {
+		w = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  STAGE\tJOB\tSTATUS\tDURATION")
+		// the jobs are listed latest first
+		for i := len(jobs) - 1; i >= 0; i-- {
+			job := jobs[i]
+			status := job.Status
+			if job.AllowFailure && job.Status == "failed" {
+				status += " (allowed)"
+			}
+			duration := ""
+			if job.Duration > 0 {
+				duration = (time.Duration(job.Duration) * time.Second).String()
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", job.Stage, job.Name, status, duration)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(mr.Description) != "" {
+		_, _ = fmt.Fprintln(out, "Description:")
+		for _, line := range strings.Split(strings.TrimRight(mr.Description, "\n"), "\n") {
+			_, _ = fmt.Fprintln(out, "  "+line)
+		}
+	}
+	return nil
+}
+
+func describeApprovals(approvals *gitlab.MergeRequestApprovals) string {
+	var by []*gitlab.BasicUser
+	for _, a := range approvals.ApprovedBy {
+		if a.User != nil {
+			by = append(by, a.User)
+		}
+	}
+	var s string
+	if approvals.ApprovalsRequired > 0 {
+		s = fmt.Sprintf("%d of %d", approvals.ApprovalsRequired-approvals.ApprovalsLeft, approvals.ApprovalsRequired)
+	} else {
+		s = fmt.Sprintf("%d", len(by))
+	}
+	if len(by) > 0 {
+		s += ", approved by " + usernames(by...)
+	}
+	return s
+}
+
+func usernames(users ...*gitlab.BasicUser) string {
+	var names []string
+	for _, u := range users {
+		if u != nil {
+			names = append(names, "@"+u.Username)
+		}
+	}
+	return orNone(strings.Join(names, ", "))
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
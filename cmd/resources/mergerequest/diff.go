@@ -0,0 +1,263 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/diff"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// threadIndent indents the threads shown below the diff lines.
+const threadIndent = "    "
+
+type DiffOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	Paths        []string
+	Discussions  bool
+	NameOnly     bool
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	diffMergeRequestExample = templates.Examples(`
+# show the changes of a merge request with the threads of its reviewers
+glctl diff mergerequest group/myapp!12
+
+# only show the changes of the files under deploy/, without the threads
+glctl diff mr 12 -p group/myapp --path=deploy/ --discussions=false
+
+# list the changed files
+glctl diff mr group/myapp!12 --name-only`)
+)
+
+func NewDiffOptions(ioStreams genericiooptions.IOStreams) *DiffOptions {
+	return &DiffOptions{
+		ioStreams:   ioStreams,
+		Discussions: true,
+	}
+}
+
+func NewDiffMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDiffOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Show the changes of a merge request with its threads inline",
+		Example:               diffMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *DiffOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	f := cmd.Flags()
+	f.StringSliceVar(&o.Paths, "path", o.Paths,
+		"Only show the changes of these files, or of the files under these directories when ending with /")
+	f.BoolVar(&o.Discussions, "discussions", o.Discussions, "Show the threads at the lines they were started on")
+	f.BoolVar(&o.NameOnly, "name-only", o.NameOnly, "Only list the changed files")
+}
+
+// Complete completes all the required options.
+func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DiffOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.NameOnly && cmd.Flags().Changed("discussions") {
+		return cmdutil.UsageErrorf(cmd, "--discussions can not be combined with --name-only")
+	}
+	return nil
+}
+
+// Run executes a diff subcommand using the specified options.
+func (o *DiffOptions) Run(args []string) error {
+	diffs, err := listDiffs(o.gitlabClient, o.project, o.iid)
+	if err != nil {
+		return err
+	}
+	diffs = o.filter(diffs)
+	if o.NameOnly {
+		for _, d := range diffs {
+			_, _ = fmt.Fprintln(o.ioStreams.Out, d.NewPath)
+		}
+		return nil
+	}
+	onLines := map[linePosition][]*gitlab.Discussion{}
+	var general []*gitlab.Discussion
+	if o.Discussions {
+		discussions, err := listDiscussions(o.gitlabClient, o.project, o.iid)
+		if err != nil {
+			return err
+		}
+		onLines, general = threads(discussions)
+	}
+	for _, d := range diffs {
+		if err = printFileDiff(o.ioStreams.Out, d, onLines); err != nil {
+			return err
+		}
+	}
+	// the threads left were started on lines which are no longer part of
+	// the diff, or on files filtered out.
+	var outdated []linePosition
+	for pos := range onLines {
+		if o.matches(pos.path) {
+			outdated = append(outdated, pos)
+		}
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].String() < outdated[j].String() })
+	for _, pos := range outdated {
+		_, _ = fileHeaderColor.Fprintln(o.ioStreams.Out, fmt.Sprintf("outdated thread on %s", pos))
+		for _, d := range onLines[pos] {
+			printThread(o.ioStreams.Out, d, threadIndent)
+		}
+	}
+	if len(o.Paths) == 0 && len(general) > 0 {
+		_, _ = fileHeaderColor.Fprintln(o.ioStreams.Out, "discussions")
+		for _, d := range general {
+			printThread(o.ioStreams.Out, d, threadIndent)
+		}
+	}
+	return nil
+}
+
+// filter keeps the changed files selected with --path.
+func (o *DiffOptions) filter(diffs []*gitlab.MergeRequestDiff) []*gitlab.MergeRequestDiff {
+	var kept []*gitlab.MergeRequestDiff
+	for _, d := range diffs {
+		if o.matches(d.NewPath) || o.matches(d.OldPath) {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+func (o *DiffOptions) matches(path string) bool {
+	if len(o.Paths) == 0 {
+		return true
+	}
+	for _, p := range o.Paths {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// listDiffs returns the changed files of the merge request.
+func listDiffs(client *gitlab.Client, pid string, iid int64) ([]*gitlab.MergeRequestDiff, error) {
+	var diffs []*gitlab.MergeRequestDiff
+	opt := &gitlab.ListMergeRequestDiffsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.MergeRequestDiff, *gitlab.Response, error) {
+			return client.MergeRequests.ListMergeRequestDiffs(pid, iid, opt, options...)
+		}, func(page []*gitlab.MergeRequestDiff) error {
+			diffs = append(diffs, page...)
+			return nil
+		})
+	return diffs, err
+}
+
+// printFileDiff writes the diff of a changed file with git like headers,
+// followed on each line by the threads started on it. The printed threads
+// are removed from onLines.
+func printFileDiff(w io.Writer, d *gitlab.MergeRequestDiff, onLines map[linePosition][]*gitlab.Discussion) error {
+	from, to := "a/"+d.OldPath, "b/"+d.NewPath
+	_, _ = fileHeaderColor.Fprintln(w, fmt.Sprintf("diff --git %s %s", from, to))
+	switch {
+	case d.NewFile:
+		_, _ = fileHeaderColor.Fprintln(w, "new file mode "+d.BMode)
+		from = "/dev/null"
+	case d.DeletedFile:
+		_, _ = fileHeaderColor.Fprintln(w, "deleted file mode "+d.AMode)
+		to = "/dev/null"
+	case d.RenamedFile:
+		_, _ = fileHeaderColor.Fprintln(w, "rename from "+d.OldPath)
+		_, _ = fileHeaderColor.Fprintln(w, "rename to "+d.NewPath)
+	}
+	if d.Diff == "" {
+		if d.TooLarge || d.Collapsed {
+			_, _ = fmt.Fprintln(w, "the diff is too large to be shown")
+		}
+		return nil
+	}
+	if err := diff.FprintLine(w, "--- "+from); err != nil {
+		return err
+	}
+	if err := diff.FprintLine(w, "+++ "+to); err != nil {
+		return err
+	}
+	return diff.Walk(d.Diff, func(line string, oldLine, newLine int64) error {
+		if err := diff.FprintLine(w, line); err != nil {
+			return err
+		}
+		var pos linePosition
+		switch {
+		case newLine > 0:
+			pos = linePosition{path: d.NewPath, line: newLine}
+		case oldLine > 0:
+			pos = linePosition{path: d.OldPath, old: true, line: oldLine}
+		default:
+			return nil
+		}
+		for _, thread := range onLines[pos] {
+			printThread(w, thread, threadIndent)
+		}
+		delete(onLines, pos)
+		// a thread on an unchanged line may be anchored to its old number
+		if newLine > 0 && oldLine > 0 {
+			old := linePosition{path: d.OldPath, old: true, line: oldLine}
+			for _, thread := range onLines[old] {
+				printThread(w, thread, threadIndent)
+			}
+			delete(onLines, old)
+		}
+		return nil
+	})
+}
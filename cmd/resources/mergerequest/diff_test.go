@@ -0,0 +1,81 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestPrintFileDiff(t *testing.T) {
+	note := func(body string, position *gitlab.NotePosition) *gitlab.Note {
+		return &gitlab.Note{Body: body, Author: gitlab.NoteAuthor{Username: "jdoe"}, Position: position}
+	}
+	discussions := []*gitlab.Discussion{{
+		ID:    "on-new-line",
+		Notes: []*gitlab.Note{note("bump", &gitlab.NotePosition{NewPath: "app.yaml", NewLine: 2})},
+	}, {
+		ID:    "on-removed-line",
+		Notes: []*gitlab.Note{note("gone", &gitlab.NotePosition{OldPath: "app.yaml", OldLine: 2})},
+	}, {
+		ID:    "on-old-context-line",
+		Notes: []*gitlab.Note{note("port", &gitlab.NotePosition{OldPath: "app.yaml", OldLine: 3})},
+	}, {
+		ID:    "outdated",
+		Notes: []*gitlab.Note{note("stale", &gitlab.NotePosition{NewPath: "app.yaml", NewLine: 40})},
+	}, {
+		ID:             "general",
+		IndividualNote: true,
+		Notes:          []*gitlab.Note{note("LGTM", nil)},
+	}, {
+		ID:    "system",
+		Notes: []*gitlab.Note{{Body: "added 1 commit", System: true}},
+	}}
+	onLines, general := threads(discussions)
+	assert.Len(t, onLines, 4)
+	assert.Equal(t, []*gitlab.Discussion{discussions[4]}, general)
+
+	var out bytes.Buffer
+	err := printFileDiff(&out, &gitlab.MergeRequestDiff{
+		OldPath: "app.yaml",
+		NewPath: "app.yaml",
+		Diff:    "@@ -1,3 +1,3 @@\n name: app\n-image: app:v1\n+image: app:v2\n port: 80\n",
+	}, onLines)
+	assert.NoError(t, err)
+	assert.Equal(t, `diff --git a/app.yaml b/app.yaml
+--- a/app.yaml
++++ b/app.yaml
+@@ -1,3 +1,3 @@
+ name: app
+-image: app:v1
+    ┌─ @jdoe
+    │ gone
+    └─
++image: app:v2
+    ┌─ @jdoe
+    │ bump
+    └─
+ port: 80
+    ┌─ @jdoe
+    │ port
+    └─
+`, out.String())
+	assert.Equal(t, map[linePosition][]*gitlab.Discussion{
+		{path: "app.yaml", line: 40}: {discussions[3]},
+	}, onLines)
+}
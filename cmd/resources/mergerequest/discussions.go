@@ -0,0 +1,171 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	threadColor     = color.New(color.FgYellow)
+	authorColor     = color.New(color.FgYellow, color.Bold)
+	resolvedColor   = color.New(color.Faint)
+	fileHeaderColor = color.New(color.Bold)
+)
+
+// linePosition locates a diff line in a file: the line number in the new
+// file, or in the old one for removed lines.
+type linePosition struct {
+	path string
+	old  bool
+	line int64
+}
+
+func (p linePosition) String() string {
+	if p.old {
+		return fmt.Sprintf("%s:-%d", p.path, p.line)
+	}
+	return fmt.Sprintf("%s:%d", p.path, p.line)
+}
+
+// listDiscussions returns every discussion of the merge request.
+func listDiscussions(client *gitlab.Client, pid string, iid int64) ([]*gitlab.Discussion, error) {
+	var discussions []*gitlab.Discussion
+	opt := &gitlab.ListMergeRequestDiscussionsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Discussion, *gitlab.Response, error) {
+			return client.Discussions.ListMergeRequestDiscussions(pid, iid, opt, options...)
+		}, func(page []*gitlab.Discussion) error {
+			discussions = append(discussions, page...)
+			return nil
+		})
+	return discussions, err
+}
+
+// threads groups the discussions by the diff line they were started on,
+// those not started on a line and those only made of system notes are
+// returned apart.
+func threads(discussions []*gitlab.Discussion) (map[linePosition][]*gitlab.Discussion, []*gitlab.Discussion) {
+	onLines := map[linePosition][]*gitlab.Discussion{}
+	var general []*gitlab.Discussion
+	for _, d := range discussions {
+		if len(userNotes(d)) == 0 {
+			continue
+		}
+		pos, ok := positionOf(d)
+		if !ok {
+			general = append(general, d)
+			continue
+		}
+		onLines[pos] = append(onLines[pos], d)
+	}
+	return onLines, general
+}
+
+// positionOf returns the diff line the discussion was started on.
+func positionOf(d *gitlab.Discussion) (linePosition, bool) {
+	p := d.Notes[0].Position
+	switch {
+	case p == nil:
+		return linePosition{}, false
+	case p.NewLine > 0:
+		return linePosition{path: p.NewPath, line: p.NewLine}, true
+	case p.OldLine > 0:
+		return linePosition{path: p.OldPath, old: true, line: p.OldLine}, true
+	}
+	return linePosition{}, false
+}
+
+// unresolved counts the resolvable discussions and those of them not resolved yet.
+func unresolved(discussions []*gitlab.Discussion) (resolvable, open int) {
+	for _, d := range discussions {
+		canResolve, isResolved, _ := resolution(d)
+		if !canResolve {
+			continue
+		}
+		resolvable++
+		if !isResolved {
+			open++
+		}
+	}
+	return resolvable, open
+}
+
+// resolution tells whether the discussion can be resolved, whether all its
+// resolvable notes are, and by whom.
+func resolution(d *gitlab.Discussion) (resolvable, resolved bool, by string) {
+	resolved = true
+	for _, n := range d.Notes {
+		if !n.Resolvable {
+			continue
+		}
+		resolvable = true
+		resolved = resolved && n.Resolved
+		if n.Resolved {
+			by = n.ResolvedBy.Username
+		}
+	}
+	return resolvable, resolvable && resolved, by
+}
+
+func userNotes(d *gitlab.Discussion) []*gitlab.Note {
+	var notes []*gitlab.Note
+	for _, n := range d.Notes {
+		if !n.System {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// printThread writes the notes of the discussion as a thread indented by
+// indent, followed by its resolution.
+func printThread(w io.Writer, d *gitlab.Discussion, indent string) {
+	notes := userNotes(d)
+	for i, n := range notes {
+		branch := "├─"
+		if i == 0 {
+			branch = "┌─"
+		}
+		_, _ = threadColor.Fprint(w, indent+branch+" ")
+		_, _ = authorColor.Fprint(w, "@"+n.Author.Username)
+		if n.CreatedAt != nil {
+			_, _ = threadColor.Fprint(w, " · "+n.CreatedAt.Local().Format(time.DateTime))
+		}
+		_, _ = fmt.Fprintln(w)
+		for _, line := range strings.Split(strings.TrimRight(n.Body, "\n"), "\n") {
+			_, _ = threadColor.Fprint(w, indent+"│ ")
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+	resolvable, resolved, by := resolution(d)
+	switch {
+	case resolved:
+		_, _ = threadColor.Fprint(w, indent+"└─ ")
+		_, _ = resolvedColor.Fprintf(w, "resolved by @%s\n", by)
+	case resolvable:
+		_, _ = threadColor.Fprintln(w, indent+"└─ unresolved")
+	default:
+		_, _ = threadColor.Fprintln(w, indent+"└─")
+	}
+}
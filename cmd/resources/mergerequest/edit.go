@@ -0,0 +1,193 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type EditOptions struct {
+	gitlabClient *gitlab.Client
+	mergeRequest *gitlab.UpdateMergeRequestOptions
+	project      string
+	iid          int64
+	Title        string
+	Description  string
+	TargetBranch string
+	Labels       []string
+	AddLabels    []string
+	RemoveLabels []string
+	Assignees    []string
+	Reviewers    []string
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	editMergeRequestExample = templates.Examples(`
+# change the title of a merge request
+glctl edit mergerequest group/myapp!12 --title="Add the login page"
+
+# label a merge request of the project given with --project
+glctl edit mr 12 -p group/myapp --add-labels=ready --remove-labels=wip
+
+# replace the assignees and reviewers of a merge request
+glctl edit mr group/myapp!12 --assignees=jdoe,asmith --reviewers=bwayne
+
+# unassign a merge request
+glctl edit mr group/myapp!12 --assignees=""`)
+
+	editMergeRequestFlags = []string{
+		"title", "desc", "target-branch", "labels", "add-labels", "remove-labels", "assignees", "reviewers",
+	}
+)
+
+func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
+	return &EditOptions{
+		ioStreams:    ioStreams,
+		mergeRequest: &gitlab.UpdateMergeRequestOptions{},
+		Out:          "simple",
+	}
+}
+
+func NewEditMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewEditOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Edit the title, labels and assignees of a merge request",
+		Example:               editMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("target-branch", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *EditOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	f := cmd.Flags()
+	f.StringVar(&o.Title, "title", o.Title, "The new title of the merge request")
+	f.StringVar(&o.Description, "desc", o.Description, "The new description of the merge request")
+	f.StringVar(&o.TargetBranch, "target-branch", o.TargetBranch, "The new branch the changes are merged into")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Comma-separated label names replacing the labels of the merge request")
+	f.StringSliceVar(&o.AddLabels, "add-labels", o.AddLabels, "Comma-separated label names added to the merge request")
+	f.StringSliceVar(&o.RemoveLabels, "remove-labels", o.RemoveLabels,
+		"Comma-separated label names removed from the merge request")
+	f.StringSliceVar(&o.Assignees, "assignees", o.Assignees,
+		"Comma-separated usernames replacing the assignees of the merge request, empty to unassign it")
+	f.StringSliceVar(&o.Reviewers, "reviewers", o.Reviewers,
+		"Comma-separated usernames replacing the reviewers of the merge request, empty to remove them")
+}
+
+// Complete completes all the required options.
+func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	if err != nil {
+		return err
+	}
+	return o.assignOptions(cmd)
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	for _, name := range editMergeRequestFlags {
+		if cmd.Flags().Changed(name) {
+			return nil
+		}
+	}
+	return cmdutil.UsageErrorf(cmd, "nothing to edit, use at least one of --%s", strings.Join(editMergeRequestFlags, ", --"))
+}
+
+// Run executes a edit subcommand using the specified options.
+func (o *EditOptions) Run(args []string) error {
+	mr, _, err := o.gitlabClient.MergeRequests.UpdateMergeRequest(o.project, o.iid, o.mergeRequest)
+	if err != nil {
+		return err
+	}
+	if o.Out != "simple" {
+		return cmdutil.PrintMergeRequestsOut(o.Out, o.ioStreams.Out, &mr.BasicMergeRequest)
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s edited\n", displayReference(o.project, &mr.BasicMergeRequest))
+	return nil
+}
+
+// assign the changed flags to the update options, the usernames are resolved
+// to user ids.
+func (o *EditOptions) assignOptions(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	if flags.Changed("title") {
+		o.mergeRequest.Title = pointer.ToString(o.Title)
+	}
+	if flags.Changed("desc") {
+		o.mergeRequest.Description = pointer.ToString(o.Description)
+	}
+	if flags.Changed("target-branch") {
+		o.mergeRequest.TargetBranch = pointer.ToString(o.TargetBranch)
+	}
+	if flags.Changed("labels") {
+		o.mergeRequest.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+	}
+	if flags.Changed("add-labels") {
+		o.mergeRequest.AddLabels = pointer.To(gitlab.LabelOptions(o.AddLabels))
+	}
+	if flags.Changed("remove-labels") {
+		o.mergeRequest.RemoveLabels = pointer.To(gitlab.LabelOptions(o.RemoveLabels))
+	}
+	if flags.Changed("assignees") {
+		ids, err := cmdutil.UserIDs(o.gitlabClient, o.Assignees)
+		if err != nil {
+			return err
+		}
+		o.mergeRequest.AssigneeIDs = pointer.To(ids)
+	}
+	if flags.Changed("reviewers") {
+		ids, err := cmdutil.UserIDs(o.gitlabClient, o.Reviewers)
+		if err != nil {
+			return err
+		}
+		o.mergeRequest.ReviewerIDs = pointer.To(ids)
+	}
+	return nil
+}
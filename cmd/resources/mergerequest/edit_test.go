@@ -0,0 +1,86 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestEditMergeRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		check     func(opt *EditOptions)
+		wantError error
+	}{{
+		name:      "missing project",
+		args:      []string{"1"},
+		flags:     []string{"--title=update test"},
+		wantError: errors.New(`the project of merge request "1" is missing, use <project>!<iid> or --project`),
+	}, {
+		name: "nothing to edit",
+		args: []string{"Group2/SubGroup3/Project13!1"},
+		wantError: errors.New("nothing to edit, use at least one of --title, --desc, --target-branch, " +
+			"--labels, --add-labels, --remove-labels, --assignees, --reviewers\n" +
+			"See 'mergerequest -h' for help and examples"),
+	}, {
+		name:  "only the changed fields are sent",
+		args:  []string{"1"},
+		flags: []string{"--project=Group2/SubGroup3/Project13", "--add-labels=triaged", "--assignees="},
+		check: func(opt *EditOptions) {
+			assert.Equal(t, "Group2/SubGroup3/Project13", opt.project)
+			assert.Equal(t, int64(1), opt.iid)
+			assert.Nil(t, opt.mergeRequest.Title)
+			assert.Nil(t, opt.mergeRequest.Labels)
+			assert.Equal(t, []string{"triaged"}, []string(*opt.mergeRequest.AddLabels))
+			assert.Empty(t, *opt.mergeRequest.AssigneeIDs)
+		},
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewEditOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.check != nil {
+				tc.check(cmdOptions)
+			}
+		})
+	}
+}
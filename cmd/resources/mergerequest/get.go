@@ -0,0 +1,174 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type ListOptions struct {
+	gitlabClient *gitlab.Client
+	Out          string
+	mergeRequest *gitlab.ListProjectMergeRequestsOptions
+	State        string
+	Author       string
+	Labels       []string
+	SourceBranch string
+	TargetBranch string
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	getMergeRequestsExample = templates.Examples(`
+# list the opened merge requests of a project
+glctl get mergerequests group1/devops
+
+# list the merged merge requests of a user
+glctl get mr group1/devops --state=merged --author=jdoe
+
+# list every opened merge request labeled bug and backend into main
+glctl get mr 100 --labels=bug,backend --target-branch=main -A
+
+# list every merge request as json
+glctl get mr 100 --state=all -A -o json`)
+
+	mergeRequestStates = []string{"opened", "closed", "locked", "merged", "all"}
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		mergeRequest: &gitlab.ListProjectMergeRequestsOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 10,
+			},
+		},
+		State:     "opened",
+		All:       false,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+func NewGetMergeRequestsCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequests [Project]",
+		Aliases:               []string{"mergerequest", "mrs", "mr"},
+		Short:                 "List the merge requests of a project",
+		Example:               getMergeRequestsExample,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Args:                  require.ExactArgs(1),
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-requests"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("source-branch", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("target-branch", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddPaginationVarFlags(cmd, &o.mergeRequest.ListOptions)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.StringVar(&o.State, "state", o.State,
+		fmt.Sprintf("Only list the merge requests in this state (%s)", strings.Join(mergeRequestStates, ", ")))
+	f.StringVar(&o.Author, "author", o.Author, "Only list the merge requests created by this username")
+	f.StringSliceVar(&o.Labels, "labels", o.Labels, "Only list the merge requests with all of these comma-separated labels")
+	f.StringVar(&o.SourceBranch, "source-branch", o.SourceBranch, "Only list the merge requests from this branch")
+	f.StringVar(&o.TargetBranch, "target-branch", o.TargetBranch, "Only list the merge requests into this branch")
+	f.BoolVarP(
+		&o.All,
+		"all",
+		"A",
+		o.All,
+		"If present, list all the merge requests of the project, page by page.",
+	)
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.mergeRequest.State = pointer.ToString(o.State)
+	if o.Author != "" {
+		o.mergeRequest.AuthorUsername = pointer.ToString(strings.TrimPrefix(o.Author, "@"))
+	}
+	if len(o.Labels) > 0 {
+		o.mergeRequest.Labels = pointer.To(gitlab.LabelOptions(o.Labels))
+	}
+	if o.SourceBranch != "" {
+		o.mergeRequest.SourceBranch = pointer.ToString(o.SourceBranch)
+	}
+	if o.TargetBranch != "" {
+		o.mergeRequest.TargetBranch = pointer.ToString(o.TargetBranch)
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && strings.TrimSpace(args[0]) == "" {
+		return fmt.Errorf("error from server (NotFound): project %s not found", args[0])
+	}
+	return validate.ValidateFlagStringValue(mergeRequestStates, cmd, "state")
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	if o.All {
+		o.mergeRequest.PerPage = o.ChunkSize
+		o.mergeRequest.Page = 1
+	}
+	printer := cmdutil.NewMergeRequestsPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.BasicMergeRequest, *gitlab.Response, error) {
+			return o.gitlabClient.MergeRequests.ListProjectMergeRequests(args[0], o.mergeRequest, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
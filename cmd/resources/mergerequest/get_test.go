@@ -0,0 +1,80 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetMergeRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantError error
+	}{{
+		name:      "project name is an empty string",
+		args:      []string{""},
+		wantError: errors.New("error from server (NotFound): project  not found"),
+	}, {
+		name:  "invalid state",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--state=draft"},
+		wantError: errors.New("'draft' is not a recognized value of 'state' flag; " +
+			"choose from [opened, closed, locked, merged, all]"),
+	}, {
+		name:  "list the opened merge requests",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--all"},
+	}, {
+		name: "list the merged merge requests of an author with labels",
+		args: []string{"Group2/SubGroup3/Project13"},
+		flags: []string{
+			"--state=merged", "--author=root", "--labels=bug,backend", "--target-branch=main", "--out=json",
+		},
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mergerequests"}
+			cmdOptions := NewListOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
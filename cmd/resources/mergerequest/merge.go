@@ -0,0 +1,152 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type MergeOptions struct {
+	gitlabClient         *gitlab.Client
+	project              string
+	iid                  int64
+	WhenPipelineSucceeds bool
+	Squash               bool
+	RemoveSourceBranch   bool
+	SHA                  string
+	Message              string
+	SquashMessage        string
+	ioStreams            genericiooptions.IOStreams
+}
+
+var (
+	mergeMergeRequestExample = templates.Examples(`
+# merge a merge request
+glctl merge mergerequest group/myapp!12
+
+# merge a merge request once its pipeline succeeds, as a single commit, and remove its branch
+glctl merge mr 12 -p group/myapp --when-pipeline-succeeds --squash --remove-source-branch
+
+# merge a merge request only if its head is still the reviewed commit
+glctl merge mr group/myapp!12 --sha=4d8f2c1e`)
+)
+
+func NewMergeOptions(ioStreams genericiooptions.IOStreams) *MergeOptions {
+	return &MergeOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewMergeMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewMergeOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Merge a merge request now or when its pipeline succeeds",
+		Example:               mergeMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *MergeOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	f := cmd.Flags()
+	f.BoolVar(&o.WhenPipelineSucceeds, "when-pipeline-succeeds", o.WhenPipelineSucceeds,
+		"Merge the merge request when its pipeline succeeds instead of now")
+	f.BoolVar(&o.Squash, "squash", o.Squash, "Squash the commits into a single commit")
+	f.BoolVar(&o.RemoveSourceBranch, "remove-source-branch", o.RemoveSourceBranch,
+		"Remove the source branch once merged")
+	f.StringVar(&o.SHA, "sha", o.SHA,
+		"The head commit of the merge request, the merge fails if the merge request has changed since")
+	f.StringVar(&o.Message, "message", o.Message, "The message of the merge commit")
+	f.StringVar(&o.SquashMessage, "squash-message", o.SquashMessage, "The message of the squash commit")
+}
+
+// Complete completes all the required options.
+func (o *MergeOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *MergeOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.SquashMessage != "" && !o.Squash {
+		return cmdutil.UsageErrorf(cmd, "--squash-message requires --squash")
+	}
+	return nil
+}
+
+// Run executes a merge subcommand using the specified options.
+func (o *MergeOptions) Run(args []string) error {
+	opt := &gitlab.AcceptMergeRequestOptions{}
+	if o.WhenPipelineSucceeds {
+		opt.AutoMerge = pointer.ToBool(true)
+	}
+	if o.Squash {
+		opt.Squash = pointer.ToBool(true)
+	}
+	if o.RemoveSourceBranch {
+		opt.ShouldRemoveSourceBranch = pointer.ToBool(true)
+	}
+	if o.SHA != "" {
+		opt.SHA = pointer.ToString(o.SHA)
+	}
+	if o.Message != "" {
+		opt.MergeCommitMessage = pointer.ToString(o.Message)
+	}
+	if o.SquashMessage != "" {
+		opt.SquashCommitMessage = pointer.ToString(o.SquashMessage)
+	}
+	mr, _, err := o.gitlabClient.MergeRequests.AcceptMergeRequest(o.project, o.iid, opt)
+	if err != nil {
+		return err
+	}
+	ref := displayReference(o.project, &mr.BasicMergeRequest)
+	if mr.State == "merged" {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s merged\n", ref)
+		return nil
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s will be merged when its pipeline succeeds\n", ref)
+	return nil
+}
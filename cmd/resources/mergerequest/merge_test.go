@@ -0,0 +1,86 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestMergeMergeRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		run       func(opt *MergeOptions, args []string) error
+		wantError error
+	}{{
+		name:      "invalid merge request",
+		args:      []string{"Group2/SubGroup3/Project13!x"},
+		wantError: errors.New(`invalid merge request "Group2/SubGroup3/Project13!x", expected <project>!<iid> or <iid> with --project`),
+	}, {
+		name:  "squash message without squash",
+		args:  []string{"Group2/SubGroup3/Project13!1"},
+		flags: []string{"--squash-message=update test"},
+		wantError: errors.New("--squash-message requires --squash\n" +
+			"See 'mergerequest -h' for help and examples"),
+	}, {
+		name:  "merge request not found",
+		args:  []string{"Group2/SubGroup3/Project13!100000"},
+		flags: []string{"--when-pipeline-succeeds", "--squash", "--remove-source-branch"},
+		run: func(opt *MergeOptions, args []string) error {
+			err := opt.Run(args)
+			assert.ErrorIs(t, err, gitlab.ErrNotFound)
+			return nil
+		},
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewMergeOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.run != nil {
+				err = tc.run(cmdOptions, tc.args)
+			} else {
+				err = cmdOptions.Run(tc.args)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// rebasePollInterval is how often the merge request is fetched while the
+// server rebases it.
+var rebasePollInterval = time.Second
+
+type RebaseOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	SkipCI       bool
+	Wait         bool
+	Timeout      time.Duration
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	rebaseMergeRequestExample = templates.Examples(`
+# rebase the source branch of a merge request onto its target branch
+glctl rebase mergerequest group/myapp!12
+
+# rebase without running a pipeline for the rebased commits, and without waiting
+glctl rebase mr 12 -p group/myapp --skip-ci --wait=false
+
+# give up when the rebase has not finished within a minute
+glctl rebase mergerequest group/myapp!12 --timeout=1m`)
+)
+
+func NewRebaseOptions(ioStreams genericiooptions.IOStreams) *RebaseOptions {
+	return &RebaseOptions{
+		ioStreams: ioStreams,
+		Wait:      true,
+		Timeout:   5 * time.Minute,
+	}
+}
+
+func NewRebaseMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewRebaseOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 "Rebase the source branch of a merge request onto its target branch",
+		Example:               rebaseMergeRequestExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *RebaseOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	flags := cmd.Flags()
+	flags.BoolVar(&o.SkipCI, "skip-ci", o.SkipCI, "Do not run a pipeline for the rebased commits")
+	flags.BoolVar(&o.Wait, "wait", o.Wait, "Wait until the server has finished the rebase")
+	flags.DurationVar(&o.Timeout, "timeout", o.Timeout, "The time to wait for the rebase, zero means forever")
+}
+
+// Complete completes all the required options.
+func (o *RebaseOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *RebaseOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Timeout < 0 {
+		return cmdutil.UsageErrorf(cmd, "--timeout can not be negative")
+	}
+	return nil
+}
+
+// Run executes a rebase subcommand using the specified options.
+func (o *RebaseOptions) Run(args []string) error {
+	opt := &gitlab.RebaseMergeRequestOptions{}
+	if o.SkipCI {
+		opt.SkipCI = pointer.ToBool(true)
+	}
+	_, err := o.gitlabClient.MergeRequests.RebaseMergeRequest(o.project, o.iid, opt)
+	if err != nil {
+		return err
+	}
+	if !o.Wait {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "rebase of merge request %s!%d started\n", o.project, o.iid)
+		return nil
+	}
+	// the rebase runs in the background, the merge request reports it in
+	// progress until it is done and then the error if it failed.
+	var deadline <-chan time.Time
+	if o.Timeout > 0 {
+		deadline = time.After(o.Timeout)
+	}
+	for {
+		mr, _, err := o.gitlabClient.MergeRequests.GetMergeRequest(o.project, o.iid, &gitlab.GetMergeRequestsOptions{
+			IncludeRebaseInProgress: pointer.ToBool(true),
+		}, cmdutil.SkipCache())
+		if err != nil {
+			return err
+		}
+		ref := displayReference(o.project, &mr.BasicMergeRequest)
+		if mr.RebaseInProgress {
+			select {
+			case <-deadline:
+				return fmt.Errorf("timed out after %s waiting for the rebase of merge request %s", o.Timeout, ref)
+			case <-time.After(rebasePollInterval):
+			}
+			continue
+		}
+		if mr.MergeError != "" {
+			return fmt.Errorf("rebase of merge request %s failed: %s", ref, mr.MergeError)
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s rebased\n", ref)
+		return nil
+	}
+}
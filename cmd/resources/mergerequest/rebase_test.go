@@ -0,0 +1,109 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRebaseMergeRequest(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := createTestMergeRequest(t, client, project, "glctl-rebase-mr")
+	tests := []struct {
+		name      string
+		flags     []string
+		want      string
+		wantError error
+	}{{
+		name:  "negative timeout",
+		flags: []string{"--timeout=-1s"},
+		wantError: errors.New("--timeout can not be negative\n" +
+			"See 'mergerequest -h' for help and examples"),
+	}, {
+		name:  "rebase without waiting",
+		flags: []string{"--wait=false", "--skip-ci"},
+		want:  "started",
+	}, {
+		name:  "rebase and wait",
+		flags: []string{"--skip-ci", "--timeout=2m"},
+		want:  "rebased",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "mergerequest"}
+			cmdOptions := NewRebaseOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			args := []string{mr.References.Full}
+			err := cmdOptions.Complete(factory, cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(args)
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, out, tc.want)
+		})
+	}
+}
+
+func TestRebaseMergeRequestTimeout(t *testing.T) {
+	// the server never finishes the rebase.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusAccepted)
+		}
+		_, _ = w.Write([]byte(`{"iid":12,"rebase_in_progress":true,"references":{"full":"group/myapp!12"}}`))
+	}))
+	defer srv.Close()
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+	defer func(interval time.Duration) { rebasePollInterval = interval }(rebasePollInterval)
+	rebasePollInterval = 10 * time.Millisecond
+
+	o := NewRebaseOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.gitlabClient, o.project, o.iid = client, "group/myapp", 12
+	o.Timeout = 50 * time.Millisecond
+	assert.EqualError(t, o.Run(nil), "timed out after 50ms waiting for the rebase of merge request group/myapp!12")
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// parseReference splits a merge request reference of the form
+// <project>!<iid> into the project and the iid. The project may be left
+// out, as in !<iid> or <iid>, and is then taken from project.
+func parseReference(ref, project string) (string, int64, error) {
+	pid, id := project, ref
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		if i > 0 {
+			pid = ref[:i]
+		}
+		id = ref[i+1:]
+	}
+	iid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || iid <= 0 {
+		return "", 0, fmt.Errorf("invalid merge request %q, expected <project>!<iid> or <iid> with --project", ref)
+	}
+	if strings.TrimSpace(pid) == "" {
+		return "", 0, fmt.Errorf("the project of merge request %q is missing, use <project>!<iid> or --project", ref)
+	}
+	return pid, iid, nil
+}
+
+// displayReference returns the full reference of the merge request, which
+// names its project.
+func displayReference(pid string, mr *gitlab.BasicMergeRequest) string {
+	if mr.References != nil && mr.References.Full != "" {
+		return mr.References.Full
+	}
+	return fmt.Sprintf("%s!%d", pid, mr.IID)
+}
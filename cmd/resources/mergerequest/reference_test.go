@@ -0,0 +1,77 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         string
+		project     string
+		wantProject string
+		wantIID     int64
+		wantError   error
+	}{{
+		name:        "full reference",
+		ref:         "Group2/SubGroup3/Project13!12",
+		project:     "other",
+		wantProject: "Group2/SubGroup3/Project13",
+		wantIID:     12,
+	}, {
+		name:        "short reference",
+		ref:         "!12",
+		project:     "Group2/SubGroup3/Project13",
+		wantProject: "Group2/SubGroup3/Project13",
+		wantIID:     12,
+	}, {
+		name:        "iid",
+		ref:         "12",
+		project:     "13",
+		wantProject: "13",
+		wantIID:     12,
+	}, {
+		name:      "missing project",
+		ref:       "12",
+		wantError: errors.New(`the project of merge request "12" is missing, use <project>!<iid> or --project`),
+	}, {
+		name:      "invalid iid",
+		ref:       "Group2/Project13!abc",
+		wantError: errors.New(`invalid merge request "Group2/Project13!abc", expected <project>!<iid> or <iid> with --project`),
+	}, {
+		name:      "negative iid",
+		ref:       "-1",
+		project:   "13",
+		wantError: errors.New(`invalid merge request "-1", expected <project>!<iid> or <iid> with --project`),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			project, iid, err := parseReference(tc.ref, tc.project)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantProject, project)
+			assert.Equal(t, tc.wantIID, iid)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergerequest
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// StateOptions closes or reopens a merge request, depending on the state
+// event sent to the server.
+type StateOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	iid          int64
+	event        string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	closeMergeRequestExample = templates.Examples(`
+# close a merge request without merging it
+glctl close mergerequest group/myapp!12
+
+# close a merge request of the project given with --project
+glctl close mr 12 --project=group/myapp`)
+
+	reopenMergeRequestExample = templates.Examples(`
+# reopen a closed merge request
+glctl reopen mergerequest group/myapp!12`)
+)
+
+func NewStateOptions(ioStreams genericiooptions.IOStreams, event string) *StateOptions {
+	return &StateOptions{
+		ioStreams: ioStreams,
+		event:     event,
+	}
+}
+
+func NewCloseMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return newStateCmd(f, NewStateOptions(ioStreams, "close"), "Close a merge request without merging it",
+		closeMergeRequestExample)
+}
+
+func NewReopenMergeRequestCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	return newStateCmd(f, NewStateOptions(ioStreams, "reopen"), "Reopen a closed merge request",
+		reopenMergeRequestExample)
+}
+
+func newStateCmd(f cmdutil.Factory, o *StateOptions, short, example string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "mergerequest",
+		Aliases:               []string{"mr"},
+		Short:                 short,
+		Example:               example,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.MergeRequestCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"merge-request"},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *StateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.project, o.iid, err = parseReference(args[0], o.project)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *StateOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Run executes a close or reopen subcommand using the specified options.
+func (o *StateOptions) Run(args []string) error {
+	mr, _, err := o.gitlabClient.MergeRequests.UpdateMergeRequest(o.project, o.iid, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: pointer.ToString(o.event),
+	})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "merge request %s %s\n", displayReference(o.project, &mr.BasicMergeRequest), mr.State)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CancelOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	id           int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	cancelPipelineExample = templates.Examples(`
+# cancel the running jobs of a pipeline
+glctl cancel pipeline 4211 --project=group/myapp`)
+)
+
+func NewCancelOptions(ioStreams genericiooptions.IOStreams) *CancelOptions {
+	return &CancelOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewCancelPipelineCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCancelOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "pipeline",
+		Aliases:               []string{"pl"},
+		Short:                 "Cancel the running jobs of a pipeline",
+		Example:               cancelPipelineExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"pipelines"},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *CancelOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.id, err = parseID(args[0])
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CancelOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	return nil
+}
+
+// Run executes a cancel subcommand using the specified options.
+func (o *CancelOptions) Run(args []string) error {
+	pipeline, _, err := o.gitlabClient.Pipelines.CancelPipelineBuild(o.project, o.id)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "pipeline #%d %s\n", pipeline.ID, pipeline.Status)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCancelPipeline(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	tests := []struct {
+		name      string
+		args      func(client *gitlab.Client) []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "invalid pipeline",
+		args:      func(*gitlab.Client) []string { return []string{"latest"} },
+		flags:     []string{"-p=" + project},
+		wantError: errors.New(`invalid pipeline id "latest"`),
+	}, {
+		name:      "project is an empty string",
+		args:      func(*gitlab.Client) []string { return []string{"1"} },
+		flags:     []string{"-p= "},
+		wantError: errors.New("--project can not be empty\nSee 'pipeline -h' for help and examples"),
+	}, {
+		name: "cancel a pipeline",
+		args: func(client *gitlab.Client) []string {
+			pipeline := createTestPipeline(t, client, project)
+			return []string{strconv.FormatInt(pipeline.ID, 10)}
+		},
+		flags:   []string{"-p=" + project},
+		wantOut: "canceled",
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "pipeline"}
+			cmdOptions := NewCancelOptions(streams)
+			cmdutil.AddProjectVarPFlag(cmd, &cmdOptions.project)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			client, err := factory.GitlabClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := tc.args(client)
+			err = cmdOptions.Complete(factory, cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(args)
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, out, tc.wantOut)
+		})
+	}
+}
+
+// createTestPipeline runs a pipeline on main of project, it is deleted again
+// when the test ends.
+func createTestPipeline(t *testing.T, client *gitlab.Client, project string) *gitlab.Pipeline {
+	t.Helper()
+	pipeline, _, err := client.Pipelines.CreatePipeline(project, &gitlab.CreatePipelineOptions{
+		Ref: pointer.ToString("main"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _, _ = client.Pipelines.CancelPipelineBuild(project, pipeline.ID)
+		_, _ = client.Pipelines.DeletePipeline(project, pipeline.ID)
+	})
+	return pipeline
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	gitlabClient *gitlab.Client
+	pipeline     *gitlab.CreatePipelineOptions
+	project      string
+	Ref          string
+	Variables    []string
+	Wait         bool
+	Watch        bool
+	Timeout      time.Duration
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	createPipelineExample = templates.Examples(`
+# run a pipeline on the default branch
+glctl create pipeline --project=group/myapp
+
+# run a pipeline on main with variables
+glctl create pipeline -p group/myapp --ref=main --variable=DEPLOY_ENV=staging --variable=DRY_RUN=false
+
+# run a pipeline and wait until it succeeds, the exit code is non-zero when it fails
+glctl create pipeline -p group/myapp --ref=v1.2.0 --wait --timeout=30m
+
+# run a pipeline and follow its jobs live
+glctl create pipeline -p group/myapp --ref=main --watch`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams: ioStreams,
+		pipeline:  &gitlab.CreatePipelineOptions{},
+		Timeout:   time.Hour,
+	}
+}
+
+func NewCreatePipelineCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "pipeline",
+		Aliases:               []string{"pl"},
+		Short:                 "Run a pipeline on a branch or a tag",
+		Example:               createPipelineExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"pipelines"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVar(&o.Ref, "ref", o.Ref, "The branch or tag to run the pipeline on, the default branch of the project if empty")
+	f.StringArrayVar(&o.Variables, "variable", o.Variables, "A KEY=VALUE variable of the pipeline, may be repeated")
+	f.BoolVar(&o.Wait, "wait", o.Wait, "Wait until the pipeline finishes and fail unless it succeeds")
+	f.BoolVarP(&o.Watch, "watch", "w", o.Watch, "Show the jobs of the pipeline live until it finishes, implies --wait")
+	f.DurationVar(&o.Timeout, "timeout", o.Timeout, "The time to wait for the pipeline with --wait, zero means forever")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if o.Watch {
+		o.Wait = true
+	}
+	if len(o.Variables) == 0 {
+		return nil
+	}
+	variables := make([]*gitlab.PipelineVariableOptions, 0, len(o.Variables))
+	for _, variable := range o.Variables {
+		key, value, ok := strings.Cut(variable, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid variable %q, expected KEY=VALUE", variable)
+		}
+		variables = append(variables, &gitlab.PipelineVariableOptions{
+			Key:          pointer.ToString(key),
+			Value:        pointer.ToString(value),
+			VariableType: pointer.To(gitlab.EnvVariableType),
+		})
+	}
+	o.pipeline.Variables = &variables
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if o.Timeout < 0 {
+		return cmdutil.UsageErrorf(cmd, "--timeout can not be negative")
+	}
+	return nil
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	if o.Ref == "" {
+		project, _, err := o.gitlabClient.Projects.GetProject(o.project, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return err
+		}
+		o.Ref = project.DefaultBranch
+	}
+	o.pipeline.Ref = pointer.ToString(o.Ref)
+	pipeline, _, err := o.gitlabClient.Pipelines.CreatePipeline(o.project, o.pipeline)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "pipeline #%d created on %s  %s\n", pipeline.ID, pipeline.Ref, pipeline.WebURL)
+	if !o.Wait {
+		return nil
+	}
+	w := &waiter{
+		client:  o.gitlabClient,
+		project: o.project,
+		timeout: o.Timeout,
+		watch:   o.Watch,
+		out:     o.ioStreams.Out,
+	}
+	return w.wait(pipeline.ID)
+}
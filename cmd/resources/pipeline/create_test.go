@@ -0,0 +1,92 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreatePipeline(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	tests := []struct {
+		name      string
+		flags     []string
+		run       func(opt *CreateOptions, args []string) error
+		wantError error
+	}{{
+		name:      "invalid variable",
+		flags:     []string{"-p=" + project, "--variable=DEPLOY_ENV"},
+		wantError: errors.New(`invalid variable "DEPLOY_ENV", expected KEY=VALUE`),
+	}, {
+		name:      "negative timeout",
+		flags:     []string{"-p=" + project, "--wait", "--timeout=-1m"},
+		wantError: errors.New("--timeout can not be negative\nSee 'pipeline -h' for help and examples"),
+	}, {
+		name:  "run a pipeline on the default branch",
+		flags: []string{"-p=" + project, "--variable=DRY_RUN=true"},
+		run: func(opt *CreateOptions, args []string) error {
+			var err error
+			out := cmdtesting.RunForStdout(opt.ioStreams, func() {
+				err = opt.Run(args)
+			})
+			var id int64
+			if _, scanErr := fmt.Sscanf(out, "pipeline #%d created on main", &id); scanErr != nil {
+				t.Fatalf("unexpected output %q: %v", out, scanErr)
+			}
+			t.Cleanup(func() {
+				_, _, _ = opt.gitlabClient.Pipelines.CancelPipelineBuild(project, id)
+				_, _ = opt.gitlabClient.Pipelines.DeletePipeline(project, id)
+			})
+			return err
+		},
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "pipeline"}
+			cmdOptions := NewCreateOptions(genericiooptions.NewTestIOStreamsForPipe())
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, nil)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			if tc.run != nil {
+				err = tc.run(cmdOptions, nil)
+			} else {
+				err = cmdOptions.Run(nil)
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type ListOptions struct {
+	gitlabClient *gitlab.Client
+	Out          string
+	pipeline     *gitlab.ListProjectPipelinesOptions
+	Status       string
+	Ref          string
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	getPipelinesExample = templates.Examples(`
+# list the latest pipelines of a project
+glctl get pipelines group1/devops
+
+# list the failed pipelines of the main branch
+glctl get pipelines group1/devops --status=failed --ref=main
+
+# list every running pipeline as json
+glctl get pipelines 100 --status=running -A -o json`)
+
+	pipelineStatuses = []string{
+		"created", "waiting_for_resource", "preparing", "pending", "running", "success", "failed", "canceled",
+		"skipped", "manual", "scheduled",
+	}
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		pipeline: &gitlab.ListProjectPipelinesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 10,
+			},
+		},
+		All:       false,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+func NewGetPipelinesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "pipelines [Project]",
+		Aliases:               []string{"pipeline", "pl"},
+		Short:                 "List the pipelines of a project, latest first",
+		Example:               getPipelinesExample,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Args:                  require.ExactArgs(1),
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"ci"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddPaginationVarFlags(cmd, &o.pipeline.ListOptions)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.StringVar(&o.Status, "status", o.Status,
+		fmt.Sprintf("Only list the pipelines in this status (%s)", strings.Join(pipelineStatuses, ", ")))
+	f.StringVar(&o.Ref, "ref", o.Ref, "Only list the pipelines of this branch or tag")
+	f.BoolVarP(
+		&o.All,
+		"all",
+		"A",
+		o.All,
+		"If present, list all the pipelines of the project, page by page.",
+	)
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if o.Status != "" {
+		o.pipeline.Status = pointer.To(gitlab.BuildStateValue(o.Status))
+	}
+	if o.Ref != "" {
+		o.pipeline.Ref = pointer.ToString(o.Ref)
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && strings.TrimSpace(args[0]) == "" {
+		return fmt.Errorf("error from server (NotFound): project %s not found", args[0])
+	}
+	if o.Status == "" {
+		return nil
+	}
+	return validate.ValidateFlagStringValue(pipelineStatuses, cmd, "status")
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	if o.All {
+		o.pipeline.PerPage = o.ChunkSize
+		o.pipeline.Page = 1
+	}
+	printer := cmdutil.NewPipelinesPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.PipelineInfo, *gitlab.Response, error) {
+			return o.gitlabClient.Pipelines.ListProjectPipelines(args[0], o.pipeline, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestGetPipelines(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantError error
+	}{{
+		name:      "project name is an empty string",
+		args:      []string{""},
+		wantError: errors.New("error from server (NotFound): project  not found"),
+	}, {
+		name:  "invalid status",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--status=done"},
+		wantError: errors.New("'done' is not a recognized value of 'status' flag; choose from [created, " +
+			"waiting_for_resource, preparing, pending, running, success, failed, canceled, skipped, manual, scheduled]"),
+	}, {
+		name:  "list the latest pipelines",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--limit=5"},
+	}, {
+		name:  "list the successful pipelines of a branch",
+		args:  []string{"Group2/SubGroup3/Project13"},
+		flags: []string{"--status=success", "--ref=main", "--all", "--out=json"},
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "pipelines"}
+			cmdOptions := NewListOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type WaitOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	id           int64
+	Watch        bool
+	Timeout      time.Duration
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	waitPipelineExample = templates.Examples(`
+# wait until a pipeline finishes, the exit code is non-zero unless it succeeds
+glctl wait pipeline 4211 --project=group/myapp
+
+# give up after 30 minutes
+glctl wait pipeline 4211 -p group/myapp --timeout=30m
+
+# follow the jobs of a pipeline live until it finishes
+glctl wait pipeline 4211 -p group/myapp --watch`)
+)
+
+func NewWaitOptions(ioStreams genericiooptions.IOStreams) *WaitOptions {
+	return &WaitOptions{
+		ioStreams: ioStreams,
+		Timeout:   time.Hour,
+	}
+}
+
+func NewWaitPipelineCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWaitOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "pipeline",
+		Aliases:               []string{"pl"},
+		Short:                 "Wait until a pipeline finishes and fail unless it succeeds",
+		Example:               waitPipelineExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"pipelines"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *WaitOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.BoolVarP(&o.Watch, "watch", "w", o.Watch, "Show the jobs of the pipeline live until it finishes")
+	f.DurationVar(&o.Timeout, "timeout", o.Timeout, "The time to wait for the pipeline, zero means forever")
+}
+
+// Complete completes all the required options.
+func (o *WaitOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.id, err = parseID(args[0])
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *WaitOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if o.Timeout < 0 {
+		return cmdutil.UsageErrorf(cmd, "--timeout can not be negative")
+	}
+	return nil
+}
+
+// Run executes a wait subcommand using the specified options.
+func (o *WaitOptions) Run(args []string) error {
+	w := &waiter{
+		client:  o.gitlabClient,
+		project: o.project,
+		timeout: o.Timeout,
+		watch:   o.Watch,
+		out:     o.ioStreams.Out,
+	}
+	return w.wait(o.id)
+}
+
+// parseID parses the id of a pipeline, a leading # is accepted.
+func parseID(value string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(value, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid pipeline id %q", value)
+	}
+	return id, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestWaitPipeline(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	tests := []struct {
+		name      string
+		args      func(client *gitlab.Client) []string
+		flags     []string
+		wantOut   string
+		wantError error
+		// wantRunError is the error of waiting, a canceled pipeline fails.
+		wantRunError string
+	}{{
+		name:      "invalid pipeline",
+		args:      func(*gitlab.Client) []string { return []string{"#x"} },
+		flags:     []string{"-p=" + project},
+		wantError: errors.New(`invalid pipeline id "#x"`),
+	}, {
+		name:      "negative timeout",
+		args:      func(*gitlab.Client) []string { return []string{"1"} },
+		flags:     []string{"-p=" + project, "--timeout=-30s"},
+		wantError: errors.New("--timeout can not be negative\nSee 'pipeline -h' for help and examples"),
+	}, {
+		name: "wait for a canceled pipeline",
+		args: func(client *gitlab.Client) []string {
+			pipeline := createTestPipeline(t, client, project)
+			if _, _, err := client.Pipelines.CancelPipelineBuild(project, pipeline.ID); err != nil {
+				t.Fatal(err)
+			}
+			return []string{strconv.FormatInt(pipeline.ID, 10)}
+		},
+		flags:        []string{"-p=" + project, "--timeout=1m"},
+		wantOut:      "canceled",
+		wantRunError: "canceled after",
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "pipeline"}
+			cmdOptions := NewWaitOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			client, err := factory.GitlabClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := tc.args(client)
+			err = cmdOptions.Complete(factory, cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(args)
+			})
+			assert.ErrorContains(t, err, tc.wantRunError)
+			assert.Contains(t, out, tc.wantOut)
+		})
+	}
+}
+
+func TestWaitPipelineRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []string
+		timeout   time.Duration
+		wantOut   string
+		wantError string
+	}{{
+		name:     "succeeds",
+		statuses: []string{"pending", "running", "success"},
+		wantOut:  "pipeline #7 pending\npipeline #7 running\npipeline #7 success\n",
+	}, {
+		name:      "fails",
+		statuses:  []string{"running", "failed"},
+		wantOut:   "pipeline #7 running\npipeline #7 failed\n",
+		wantError: "pipeline #7 failed after",
+	}, {
+		name:      "times out",
+		statuses:  []string{"running"},
+		timeout:   50 * time.Millisecond,
+		wantOut:   "pipeline #7 running\n",
+		wantError: "timed out after 50ms waiting for pipeline #7, it is running",
+	}}
+	defer func(interval time.Duration) { pipelinePollInterval = interval }(pipelinePollInterval)
+	pipelinePollInterval = 10 * time.Millisecond
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// the server answers with the next status on every poll and
+			// keeps the last one.
+			polls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[min(polls, len(tc.statuses)-1)]
+				polls++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":7,"status":"` + status + `"}`))
+			}))
+			defer srv.Close()
+			client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			assert.NoError(t, err)
+
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			o := NewWaitOptions(streams)
+			o.gitlabClient, o.project, o.id, o.Timeout = client, "group/myapp", 7, tc.timeout
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = o.Run(nil)
+			})
+			if tc.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantError)
+			}
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+}
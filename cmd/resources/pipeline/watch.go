@@ -0,0 +1,194 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/util/progress"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	// pipelinePollInterval is the time between two requests for the status
+	// of a pipeline.
+	pipelinePollInterval = 5 * time.Second
+
+	// watchRefreshInterval is the time between two draws of the job table,
+	// the spinners move at this pace while the jobs are polled.
+	watchRefreshInterval = 200 * time.Millisecond
+
+	// finalStatuses are the statuses a pipeline keeps unless it is retried or
+	// a manual job is played, only success is not a failure.
+	finalStatuses = map[string]bool{
+		"success":  true,
+		"failed":   true,
+		"canceled": true,
+		"skipped":  true,
+		"manual":   true,
+	}
+)
+
+// waiter polls a pipeline until it reaches a final status, with watch the
+// jobs of the pipeline are shown in a table redrawn as they progress.
+type waiter struct {
+	client  *gitlab.Client
+	project string
+	timeout time.Duration
+	watch   bool
+	out     io.Writer
+}
+
+// wait waits for the pipeline and returns an error when it did not succeed
+// or did not finish within the timeout.
+func (w *waiter) wait(id int64) error {
+	var (
+		deadline <-chan time.Time
+		pipeline *gitlab.Pipeline
+		jobs     []*gitlab.Job
+		table    *progress.Table
+		status   string
+		polled   time.Time
+	)
+	if w.timeout > 0 {
+		deadline = time.After(w.timeout)
+	}
+	start := time.Now()
+	for {
+		if time.Since(polled) >= pipelinePollInterval {
+			polled = time.Now()
+			var err error
+			if pipeline, _, err = w.client.Pipelines.GetPipeline(w.project, id, cmdutil.SkipCache()); err != nil {
+				return err
+			}
+			if pipeline.Status != status {
+				status = pipeline.Status
+				if !w.watch {
+					_, _ = fmt.Fprintf(w.out, "pipeline #%d %s\n", pipeline.ID, pipeline.Status)
+				}
+			}
+			if w.watch {
+				if jobs, err = w.jobs(id); err != nil {
+					return err
+				}
+				if table == nil {
+					_, _ = fmt.Fprintf(w.out, "pipeline #%d on %s  %s\n", pipeline.ID, pipeline.Ref, pipeline.WebURL)
+					table = progress.NewTable(w.out, "STAGE", "JOB", "STATUS", "DURATION")
+				}
+			}
+		}
+		if table != nil {
+			if err := table.Draw(jobRows(jobs)); err != nil {
+				return err
+			}
+		}
+		if finalStatuses[pipeline.Status] {
+			err := result(pipeline, time.Since(start))
+			if err == nil && w.watch {
+				_, _ = fmt.Fprintf(w.out, "pipeline #%d succeeded after %s\n", pipeline.ID, time.Since(start).Round(time.Second))
+			}
+			return err
+		}
+		refresh := pipelinePollInterval
+		if w.watch {
+			refresh = watchRefreshInterval
+		}
+		select {
+		case <-deadline:
+			return fmt.Errorf("timed out after %s waiting for pipeline #%d, it is %s", w.timeout, id, pipeline.Status)
+		case <-time.After(refresh):
+		}
+	}
+}
+
+// jobs returns the jobs of the pipeline in the order they were created.
+func (w *waiter) jobs(id int64) ([]*gitlab.Job, error) {
+	var jobs []*gitlab.Job
+	opt := &gitlab.ListJobsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Job, *gitlab.Response, error) {
+			return w.client.Jobs.ListPipelineJobs(w.project, id, opt, append(options, cmdutil.SkipCache())...)
+		}, func(page []*gitlab.Job) error {
+			jobs = append(jobs, page...)
+			return nil
+		})
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs, err
+}
+
+// result returns the error of a pipeline in a final status other than success.
+func result(pipeline *gitlab.Pipeline, elapsed time.Duration) error {
+	switch pipeline.Status {
+	case "success":
+		return nil
+	case "manual":
+		return fmt.Errorf("pipeline #%d is blocked by a manual job: %s", pipeline.ID, pipeline.WebURL)
+	default:
+		return fmt.Errorf("pipeline #%d %s after %s: %s", pipeline.ID, pipeline.Status,
+			elapsed.Round(time.Second), pipeline.WebURL)
+	}
+}
+
+func jobRows(jobs []*gitlab.Job) []progress.Row {
+	rows := make([]progress.Row, 0, len(jobs))
+	for _, job := range jobs {
+		status := job.Status
+		if job.Status == "failed" && job.AllowFailure {
+			status += " (allowed)"
+		}
+		rows = append(rows, progress.Row{
+			Key:     fmt.Sprint(job.ID),
+			Status:  jobStatus(job),
+			Columns: []string{job.Stage, job.Name, status},
+			Detail:  jobDuration(job),
+		})
+	}
+	return rows
+}
+
+func jobStatus(job *gitlab.Job) progress.EventStatus {
+	switch job.Status {
+	case "success":
+		return progress.Done
+	case "failed":
+		if job.AllowFailure {
+			return progress.Warning
+		}
+		return progress.Error
+	case "canceled", "skipped":
+		return progress.Warning
+	case "running":
+		return progress.Running
+	default:
+		return progress.Pending
+	}
+}
+
+// jobDuration returns how long the job ran, or has been running.
+func jobDuration(job *gitlab.Job) string {
+	switch {
+	case job.Duration > 0:
+		return (time.Duration(job.Duration * float64(time.Second))).Round(time.Second).String()
+	case job.Status == "running" && job.StartedAt != nil:
+		return time.Since(*job.StartedAt).Round(time.Second).String()
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/util/progress"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestJobRows(t *testing.T) {
+	jobs := []*gitlab.Job{
+		{ID: 1, Stage: "build", Name: "compile", Status: "success", Duration: 61.4},
+		{ID: 2, Stage: "test", Name: "lint", Status: "failed", AllowFailure: true},
+		{ID: 3, Stage: "test", Name: "rspec", Status: "failed"},
+		{ID: 4, Stage: "test", Name: "e2e", Status: "running"},
+		{ID: 5, Stage: "deploy", Name: "production", Status: "manual"},
+	}
+	assert.Equal(t, []progress.Row{
+		{Key: "1", Status: progress.Done, Columns: []string{"build", "compile", "success"}, Detail: "1m1s"},
+		{Key: "2", Status: progress.Warning, Columns: []string{"test", "lint", "failed (allowed)"}},
+		{Key: "3", Status: progress.Error, Columns: []string{"test", "rspec", "failed"}},
+		{Key: "4", Status: progress.Running, Columns: []string{"test", "e2e", "running"}},
+		{Key: "5", Status: progress.Pending, Columns: []string{"deploy", "production", "manual"}},
+	}, jobRows(jobs))
+}
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		wantError error
+	}{{
+		name:   "success",
+		status: "success",
+	}, {
+		name:      "failed",
+		status:    "failed",
+		wantError: errors.New("pipeline #7 failed after 1m30s: https://gitlab.example.com/pipelines/7"),
+	}, {
+		name:      "canceled",
+		status:    "canceled",
+		wantError: errors.New("pipeline #7 canceled after 1m30s: https://gitlab.example.com/pipelines/7"),
+	}, {
+		name:      "blocked",
+		status:    "manual",
+		wantError: errors.New("pipeline #7 is blocked by a manual job: https://gitlab.example.com/pipelines/7"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline := &gitlab.Pipeline{ID: 7, Status: tc.status, WebURL: "https://gitlab.example.com/pipelines/7"}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, result(pipeline, 90*time.Second+300*time.Millisecond))
+		})
+	}
+}
+
+func TestParseID(t *testing.T) {
+	id, err := parseID("#42")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	_, err = parseID("latest")
+	cmdtesting.ErrorAssertionWithEqual(t, errors.New(`invalid pipeline id "latest"`), err)
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -93,6 +94,7 @@ func NewCreateProjectCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams
 		SuggestFor: []string{},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("namespace", completion.GroupCompletionFunc(f)))
 	return cmd
 }
 
@@ -15,6 +15,10 @@
 package project
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/AlekSi/pointer"
 	"github.com/spf13/cobra"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -24,12 +28,15 @@ import (
 
 	"github.com/huhouhua/glctl/cmd/require"
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/util/editor"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
 type EditOptions struct {
 	gitlabClient *gitlab.Client
 	project      *gitlab.EditProjectOptions
+	Interactive  bool
 	Out          string
 	ioStreams    genericiooptions.IOStreams
 }
@@ -42,7 +49,10 @@ glctl edit project ProjectX --desc="A go project"
 glctl edit project GroupX/ProjectX --merge-method=rebase_merge 
 
 # update a project with id (23)
-glctl edit project 3 --desc="A go project"`)
+glctl edit project 3 --desc="A go project"
+
+# edit the settings of a project as YAML in $EDITOR
+glctl edit project GroupX/ProjectX -i`)
 )
 
 func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
@@ -62,7 +72,7 @@ func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
 }
 
 func NewEditProjectCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
-	o := NewCreateOptions(ioStreams)
+	o := NewEditOptions(ioStreams)
 	cmd := &cobra.Command{
 		Use:                   "project",
 		Aliases:               []string{"p"},
@@ -71,6 +81,7 @@ func NewEditProjectCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Args:                  require.ExactArgs(1),
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -93,6 +104,13 @@ func (o *EditOptions) AddFlags(cmd *cobra.Command) {
 	f.StringVar(o.project.Name, "name", *o.project.Name, "New project name")
 	f.StringVar(o.project.Path, "path", *o.project.Path, "New project path")
 	f.StringVar(o.project.DefaultBranch, "default-branch", *o.project.DefaultBranch, "The default branch")
+	f.BoolVarP(
+		&o.Interactive,
+		"interactive",
+		"i",
+		o.Interactive,
+		"Edit the current settings as YAML in the editor, only the changed ones are sent",
+	)
 
 	f.String("issues_access_level", "", "issues access level "+
 		"(disabled,enabled,private,public)")
@@ -137,25 +155,62 @@ func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		return err
 	}
 	o.gitlabClient = gitlabClient
+	if o.Interactive {
+		o.project = &gitlab.EditProjectOptions{}
+		return nil
+	}
 	return o.assignOptions(cmd)
 }
 
 // Validate makes sure there is no discrepency in command options.
 func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.Interactive {
+		return validate.ValidateInteractiveFlags(cmd)
+	}
 	if err := validate.ValidateVisibilityFlagValue(cmd); err != nil {
 		return err
 	}
-	if err := validate.ValidateMergeMethodValue(cmd); err != nil {
-		return err
+	if !cmd.Flag("merge-method").Changed {
+		return nil
 	}
-	return nil
+	return validate.ValidateMergeMethodValue(cmd)
 }
 
 // Run executes a list subcommand using the specified options.
 func (o *EditOptions) Run(args []string) error {
+	if o.Interactive {
+		return o.runInteractive(args[0])
+	}
 	project, _, err := o.gitlabClient.Projects.EditProject(args[0], o.project)
 	if err != nil {
 		return err
 	}
 	return cmdutil.PrintProjectsOut(o.Out, o.ioStreams.Out, project)
 }
+
+// runInteractive edits the settings of the project in the editor and sends
+// the changed ones.
+func (o *EditOptions) runInteractive(pid string) error {
+	current, _, err := o.gitlabClient.Projects.GetProject(pid, &gitlab.GetProjectOptions{})
+	if err != nil {
+		return err
+	}
+	changes, err := editor.NewDefaultEditor([]string{"EDITOR"}).
+		EditFields(fmt.Sprintf("%s-edit-project-", filepath.Base(os.Args[0])), current, o.project)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(o.ioStreams.ErrOut, "Edit cancelled, no changes made.")
+		return nil
+	}
+	project, _, err := o.gitlabClient.Projects.EditProject(current.ID, o.project)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "  %s\n", change)
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "%s edited\n", project.PathWithNamespace)
+	return nil
+}
@@ -26,8 +26,26 @@ import (
 // If a flag's default value is not changed by the caller,
 // it's value will not be assigned to the associated gitlab.EditProjectOptions field.
 func (o *EditOptions) assignOptions(cmd *cobra.Command) error {
-	if cmd.Flag("desc").Changed {
-		o.project.Description = pointer.ToString(cmdutil.GetFlagString(cmd, "desc"))
+	if !cmd.Flag("name").Changed {
+		o.project.Name = nil
+	}
+	if !cmd.Flag("path").Changed {
+		o.project.Path = nil
+	}
+	if !cmd.Flag("default-branch").Changed {
+		o.project.DefaultBranch = nil
+	}
+	if !cmd.Flag("lfs-enabled").Changed {
+		o.project.LFSEnabled = nil
+	}
+	if !cmd.Flag("request-access-enabled").Changed {
+		o.project.RequestAccessEnabled = nil
+	}
+	if !cmd.Flag("visibility").Changed {
+		o.project.Visibility = nil
+	}
+	if !cmd.Flag("desc").Changed {
+		o.project.Description = nil
 	}
 	if cmd.Flag("issues_access_level").Changed {
 		o.project.IssuesAccessLevel = pointer.To(
@@ -13,3 +13,103 @@
 // limitations under the License.
 
 package project
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+)
+
+// editProjectFlags returns the options of edit project with flags set, the
+// way the command parses them.
+func editProjectFlags(t *testing.T, flags map[string]string) (*EditOptions, *cobra.Command) {
+	streams, _, _, _ := genericiooptions.NewTestIOStreams()
+	o := NewEditOptions(streams)
+	cmd := &cobra.Command{}
+	o.AddFlags(cmd)
+	for name, value := range flags {
+		assert.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return o, cmd
+}
+
+func TestEditProjectCmd(t *testing.T) {
+	streams, _, _, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewEditProjectCmd(nil, streams)
+	// edit project used to run with the options of create project, which
+	// have no --interactive and created a project instead of editing it.
+	assert.NotNil(t, cmd.Flags().Lookup("interactive"))
+}
+
+func TestEditProjectAssignOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  *gitlab.EditProjectOptions
+	}{{
+		// the defaults of the flags were sent before, renaming the project to
+		// "", making it private and moving its default branch to main.
+		name:  "only the changed flags are sent",
+		flags: map[string]string{"desc": "A go project"},
+		want:  &gitlab.EditProjectOptions{Description: pointer.ToString("A go project")},
+	}, {
+		name:  "flags set to their default",
+		flags: map[string]string{"name": "", "visibility": "private", "lfs-enabled": "false"},
+		want: &gitlab.EditProjectOptions{
+			Name:       pointer.ToString(""),
+			LFSEnabled: pointer.ToBool(false),
+			Visibility: pointer.To(gitlab.PrivateVisibility),
+		},
+	}, {
+		name:  "merge method",
+		flags: map[string]string{"merge-method": "ff"},
+		want:  &gitlab.EditProjectOptions{MergeMethod: pointer.To(gitlab.FastForwardMerge)},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o, cmd := editProjectFlags(t, tc.flags)
+			assert.NoError(t, o.assignOptions(cmd))
+			assert.Equal(t, tc.want, o.project)
+		})
+	}
+}
+
+func TestEditProjectValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		wantError string
+	}{{
+		// an unset --merge-method failed the validation before, so that no
+		// project could be edited without it.
+		name:  "without merge method",
+		flags: map[string]string{"desc": "A go project"},
+	}, {
+		name:  "merge method",
+		flags: map[string]string{"merge-method": "rebase_merge"},
+	}, {
+		name:      "unknown merge method",
+		flags:     map[string]string{"merge-method": "squash"},
+		wantError: "'squash' is not a recognized value of 'merge-method' flag; choose from [merge, ff, rebase_merge]",
+	}, {
+		name:      "interactive with field flags",
+		flags:     map[string]string{"interactive": "true", "desc": "A go project"},
+		wantError: "--interactive can not be combined with --desc",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o, cmd := editProjectFlags(t, tc.flags)
+			err := o.Validate(cmd, []string{"group/project"})
+			if tc.wantError != "" {
+				assert.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
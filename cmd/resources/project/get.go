@@ -26,6 +26,7 @@ import (
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
 	"github.com/huhouhua/glctl/cmd/validate"
 )
 
@@ -39,6 +40,8 @@ type ListOptions struct {
 	project      *gitlab.ListProjectsOptions
 	ProjectId    *string
 	AllGroups    bool
+	Limit        int64
+	ChunkSize    int64
 }
 
 var (
@@ -47,7 +50,10 @@ var (
 glctl get projects
 
 # get all projects from a group
-glctl get projects --all-groups=true`)
+glctl get projects --all-groups=true
+
+# stream at most 1000 projects in chunks of 200
+glctl get projects -A --limit=1000 --chunk-size=200`)
 )
 
 func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
@@ -73,6 +79,7 @@ func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
 			},
 		},
 		AllGroups: false,
+		ChunkSize: cmdutil.DefaultChunkSize,
 		Out:       "simple",
 	}
 }
@@ -85,6 +92,7 @@ func NewGetProjectsCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		Example:               getProjectsExample,
 		DisableFlagsInUseLine: true,
 		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete(f, cmd, args))
 			cmdutil.CheckErr(o.Validate(cmd, args))
@@ -93,6 +101,7 @@ func NewGetProjectsCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams)
 		SuggestFor: []string{"project"},
 	}
 	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("group", completion.GroupCompletionFunc(f)))
 	return cmd
 }
 
@@ -107,6 +116,8 @@ func (o *ListOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddOwnedVarFlag(cmd, o.project.Owned)
 	cmdutil.AddPaginationVarFlags(cmd, &o.project.ListOptions)
 	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
 	f := cmd.Flags()
 	f.BoolVar(o.project.Archived, "archived", *o.project.Archived,
 		"Limit by archived status")
@@ -140,6 +151,18 @@ func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		return err
 	}
 	o.project.Visibility = gitlab.Ptr(gitlab.VisibilityValue(o.Visibility))
+	if o.AllGroups {
+		o.project.PerPage = o.ChunkSize
+		o.project.Page = 1
+		// projects support keyset pagination ordered by id, which keeps
+		// walking a large instance cheap for the server. created_at is the
+		// default order and follows the ids.
+		if order := *o.project.OrderBy; order == "id" || order == "created_at" {
+			o.project.Pagination = "keyset"
+			o.project.OrderBy = pointer.ToString("id")
+			o.project.Page = 0
+		}
+	}
 	opt, err := json.Marshal(o.project)
 	if err != nil {
 		return err
@@ -147,6 +170,11 @@ func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 	if err = json.Unmarshal(opt, o.group); err != nil {
 		return err
 	}
+	if o.group.Pagination != "" {
+		// group projects are only listed with offset pagination.
+		o.group.Pagination = ""
+		o.group.Page = 1
+	}
 	return nil
 }
 
@@ -170,31 +198,17 @@ func (o *ListOptions) Run(args []string) error {
 		}
 		return cmdutil.PrintProjectsOut(o.Out, o.ioStreams.Out, project)
 	}
-	var projects []*gitlab.Project
-	var err error
-
+	list := func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return o.gitlabClient.Projects.ListProjects(o.project, options...)
+	}
 	if strings.TrimSpace(o.FromGroup) != "" {
-		projects, _, err = o.gitlabClient.Groups.ListGroupProjects(o.FromGroup, o.group)
-	} else {
-		if o.AllGroups {
-			o.project.PerPage = 100
-			o.project.Page = 1
-		}
-		for {
-			var portion []*gitlab.Project
-			portion, _, err = o.gitlabClient.Projects.ListProjects(o.project)
-			if err != nil {
-				return nil
-			}
-			projects = append(projects, portion...)
-			if cap(portion) == 0 || !o.AllGroups {
-				break
-			}
-			o.project.Page++
+		list = func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+			return o.gitlabClient.Groups.ListGroupProjects(o.FromGroup, o.group, options...)
 		}
 	}
-	if err != nil {
-		return nil
+	printer := cmdutil.NewProjectsPrinter(o.Out, o.ioStreams.Out)
+	if err := cmdutil.ListPages(o.AllGroups, o.Limit, list, printer.PrintChunk); err != nil {
+		return err
 	}
-	return cmdutil.PrintProjectsOut(o.Out, o.ioStreams.Out, projects...)
+	return printer.Flush()
 }
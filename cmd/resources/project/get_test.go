@@ -53,6 +53,15 @@ func TestGetProjects(t *testing.T) {
 			opt.project.ListOptions.PerPage = 100
 		},
 		wantError: nil,
+	}, {
+		name: "list all projects with limit and chunk size",
+		args: []string{},
+		optionsFunc: func(opt *ListOptions) {
+			opt.AllGroups = true
+			opt.Limit = 5
+			opt.ChunkSize = 2
+		},
+		wantError: nil,
 	}, {
 		name: "desc sort",
 		args: []string{},
@@ -0,0 +1,283 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	gitlabClient  *gitlab.Client
+	project       string
+	Name          string
+	Ref           string
+	Message       string
+	Notes         string
+	NotesFile     string
+	GenerateNotes bool
+	Assets        []string
+	PackageName   string
+	Out           string
+	assets        []asset
+	ioStreams     genericiooptions.IOStreams
+}
+
+// asset is a local file uploaded to the generic package registry and linked
+// to the release.
+type asset struct {
+	path string
+	name string
+}
+
+var (
+	createReleaseExample = templates.Examples(`
+# release the existing tag v1.2.0 with the notes of a file
+glctl create release v1.2.0 -p group/myapp --notes-file=CHANGELOG.md
+
+# tag main as v1.2.0 and release it with notes generated from the commits since the previous tag
+glctl create release v1.2.0 -p group/myapp --ref=main --generate-notes
+
+# upload the binaries to the package registry and link them to the release
+glctl create release v1.2.0 -p group/myapp --notes-file=CHANGELOG.md --asset=./dist/app.tar.gz --asset=./dist/app.zip`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams:   ioStreams,
+		PackageName: "release",
+		Out:         "simple",
+	}
+}
+
+func NewCreateReleaseCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "release",
+		Aliases:               []string{"rel"},
+		Short:                 "Release a tag with notes and assets, the tag is created from --ref when missing",
+		Example:               createReleaseExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.TagCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVar(&o.Name, "name", o.Name, "The title of the release, the tag name by default")
+	f.StringVarP(&o.Ref, "ref", "r", o.Ref,
+		"The branch name or commit SHA to create the tag from when it does not exist")
+	f.StringVarP(&o.Message, "message", "m", o.Message,
+		"Used with '--ref'. Create the missing tag as an annotated tag with this message")
+	f.StringVar(&o.Notes, "notes", o.Notes, "The release notes, in markdown")
+	f.StringVar(&o.NotesFile, "notes-file", o.NotesFile, "Read the release notes from a markdown file")
+	f.BoolVar(&o.GenerateNotes, "generate-notes", o.GenerateNotes,
+		"Generate the release notes from the changelog trailers of the commits since the previous tag")
+	f.StringArrayVar(&o.Assets, "asset", o.Assets,
+		"Upload a file to the generic package registry and link it to the release, can be repeated")
+	f.StringVar(&o.PackageName, "package-name", o.PackageName,
+		"The generic package the assets are uploaded to, the tag is the version of the package")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	if o.NotesFile != "" {
+		notes, err := cmdutil.ReadFile(o.NotesFile)
+		if err != nil {
+			return err
+		}
+		o.Notes = string(notes)
+	}
+	o.assets, err = assets(o.Assets)
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the tag of the release can not be empty")
+	}
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	var notes []string
+	for _, name := range []string{"notes", "notes-file", "generate-notes"} {
+		if cmd.Flags().Changed(name) {
+			notes = append(notes, "--"+name)
+		}
+	}
+	if len(notes) > 1 {
+		return cmdutil.UsageErrorf(cmd, "%s can not be combined, use only one of them", strings.Join(notes, " and "))
+	}
+	if o.Message != "" && o.Ref == "" {
+		return cmdutil.UsageErrorf(cmd, "--message can only be used with --ref")
+	}
+	if len(o.assets) > 0 && strings.TrimSpace(o.PackageName) == "" {
+		return cmdutil.UsageErrorf(cmd, "--package-name can not be empty")
+	}
+	return nil
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	tag := args[0]
+	release := &gitlab.CreateReleaseOptions{
+		TagName: pointer.ToString(tag),
+	}
+	if o.Name != "" {
+		release.Name = pointer.ToString(o.Name)
+	}
+	if o.Ref != "" {
+		release.Ref = pointer.ToString(o.Ref)
+	}
+	if o.Message != "" {
+		release.TagMessage = pointer.ToString(o.Message)
+	}
+	if o.GenerateNotes {
+		notes, err := o.generateNotes(tag)
+		if err != nil {
+			return err
+		}
+		o.Notes = notes
+	}
+	if o.Notes != "" {
+		release.Description = pointer.ToString(o.Notes)
+	}
+	if len(o.assets) > 0 {
+		links, err := o.upload(tag)
+		if err != nil {
+			return err
+		}
+		release.Assets = &gitlab.ReleaseAssetsOptions{Links: links}
+	}
+	created, _, err := o.gitlabClient.Releases.CreateRelease(o.project, release)
+	if err != nil {
+		return err
+	}
+	return cmdutil.PrintReleasesOut(o.Out, o.ioStreams.Out, created)
+}
+
+// generateNotes returns the changelog GitLab generates from the trailers of
+// the commits between the previous tag and the released one.
+func (o *CreateOptions) generateNotes(tag string) (string, error) {
+	to := tag
+	if o.Ref != "" {
+		to = o.Ref
+	}
+	changelog, _, err := o.gitlabClient.Repositories.GenerateChangelogData(o.project, gitlab.GenerateChangelogDataOptions{
+		Version: pointer.ToString(tag),
+		To:      pointer.ToString(to),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the notes of release %s: %w", tag, err)
+	}
+	return changelog.Notes, nil
+}
+
+// upload publishes the assets to the generic package named after
+// --package-name, in the version of the tag, and returns their links.
+func (o *CreateOptions) upload(tag string) ([]*gitlab.ReleaseAssetLinkOptions, error) {
+	links := make([]*gitlab.ReleaseAssetLinkOptions, 0, len(o.assets))
+	for _, a := range o.assets {
+		if err := o.publish(tag, a); err != nil {
+			return nil, err
+		}
+		path, err := o.gitlabClient.GenericPackages.FormatPackageURL(o.project, o.PackageName, tag, a.name)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.ErrOut, "asset %s uploaded to package %s %s\n", a.name, o.PackageName, tag)
+		links = append(links, &gitlab.ReleaseAssetLinkOptions{
+			Name:            pointer.ToString(a.name),
+			URL:             pointer.ToString(o.gitlabClient.BaseURL().String() + path),
+			DirectAssetPath: pointer.ToString("/" + a.name),
+			LinkType:        pointer.To(gitlab.PackageLinkType),
+		})
+	}
+	return links, nil
+}
+
+func (o *CreateOptions) publish(tag string, a asset) error {
+	f, err := os.Open(a.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, _, err = o.gitlabClient.GenericPackages.PublishPackageFile(o.project, o.PackageName, tag, a.name, f, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upload asset %s: %w", a.path, err)
+	}
+	return nil
+}
+
+// assets checks the files exist and returns them named after their base
+// name, which must be unique as it is the name of the file in the package.
+func assets(paths []string) ([]asset, error) {
+	var (
+		result []asset
+		seen   = map[string]string{}
+	)
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s not found", path)
+		}
+		if !fi.Mode().IsRegular() {
+			return nil, fmt.Errorf("asset %s is not a file", path)
+		}
+		name := filepath.Base(path)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("assets %s and %s have the same name %s", other, path, name)
+		}
+		seen[name] = path
+		result = append(result, asset{path: path, name: name})
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestAssets(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.tar.gz", "app.zip", filepath.Join("linux", "app.tar.gz")} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(name), 0o644))
+	}
+	tests := []struct {
+		name      string
+		paths     []string
+		want      []asset
+		wantError error
+	}{{
+		name: "no assets",
+	}, {
+		name:  "named after the base name",
+		paths: []string{filepath.Join(dir, "app.tar.gz"), filepath.Join(dir, "app.zip")},
+		want: []asset{
+			{path: filepath.Join(dir, "app.tar.gz"), name: "app.tar.gz"},
+			{path: filepath.Join(dir, "app.zip"), name: "app.zip"},
+		},
+	}, {
+		name:  "same base name",
+		paths: []string{filepath.Join(dir, "app.tar.gz"), filepath.Join(dir, "linux", "app.tar.gz")},
+		wantError: errors.New("assets " + filepath.Join(dir, "app.tar.gz") + " and " +
+			filepath.Join(dir, "linux", "app.tar.gz") + " have the same name app.tar.gz"),
+	}, {
+		name:      "missing file",
+		paths:     []string{filepath.Join(dir, "app.deb")},
+		wantError: errors.New("asset " + filepath.Join(dir, "app.deb") + " not found"),
+	}, {
+		name:      "directory",
+		paths:     []string{filepath.Join(dir, "linux")},
+		wantError: errors.New("asset " + filepath.Join(dir, "linux") + " is not a file"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := assets(tc.paths)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateReleaseValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		wantError error
+	}{{
+		name: "no notes",
+	}, {
+		name:  "notes file",
+		flags: map[string]string{"notes-file": "CHANGELOG.md"},
+	}, {
+		name:  "tag created with a message",
+		flags: map[string]string{"ref": "main", "message": "Stable", "generate-notes": "true"},
+	}, {
+		name:  "several sources of notes",
+		flags: map[string]string{"notes": "Fixes", "notes-file": "CHANGELOG.md"},
+		wantError: errors.New(
+			"--notes and --notes-file can not be combined, use only one of them\nSee ' -h' for help and examples"),
+	}, {
+		name:      "message without ref",
+		flags:     map[string]string{"message": "Stable"},
+		wantError: errors.New("--message can only be used with --ref\nSee ' -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			o := NewCreateOptions(genericiooptions.NewTestIOStreamsDiscard())
+			o.AddFlags(cmd)
+			o.project = "group/myapp"
+			for name, value := range tc.flags {
+				assert.NoError(t, cmd.Flags().Set(name, value))
+			}
+			err := o.Validate(cmd, []string{"v1.2.0"})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type DeleteOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	deleteReleaseExample = templates.Examples(`
+# delete the release v1.2.0 of project group/myapp, the tag and the uploaded assets are kept
+glctl delete release v1.2.0 --project=group/myapp`)
+)
+
+func NewDeleteOptions(ioStreams genericiooptions.IOStreams) *DeleteOptions {
+	return &DeleteOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewDeleteReleaseCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDeleteOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "release",
+		Aliases:               []string{"rel"},
+		Short:                 "Delete the release of a tag",
+		Example:               deleteReleaseExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.TagCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{},
+	}
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *DeleteOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DeleteOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the tag of the release can not be empty")
+	}
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	return nil
+}
+
+// Run executes a delete subcommand using the specified options.
+func (o *DeleteOptions) Run(args []string) error {
+	if _, _, err := o.gitlabClient.Releases.DeleteRelease(o.project, args[0]); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "release %s deleted from project %s\n", args[0], o.project)
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestDeleteRelease(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createTestRelease(t, client, project, "glctl-delete-release")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "empty tag",
+		args:      []string{" "},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("the tag of the release can not be empty\nSee 'release -h' for help and examples"),
+	}, {
+		name:      "empty project",
+		args:      []string{"glctl-delete-release"},
+		flags:     []string{"-p= "},
+		wantError: errors.New("--project can not be empty\nSee 'release -h' for help and examples"),
+	}, {
+		name:    "delete a release",
+		args:    []string{"glctl-delete-release"},
+		flags:   []string{"-p=" + project},
+		wantOut: "release glctl-delete-release deleted from project " + project + "\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "release"}
+			cmdOptions := NewDeleteOptions(streams)
+			cmdutil.AddProjectVarPFlag(cmd, &cmdOptions.project)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+	// the tag of the release is kept.
+	_, _, err = client.Tags.GetTag(project, "glctl-delete-release")
+	assert.NoError(t, err)
+}
+
+// createTestRelease releases a new tag of main of project, the release and
+// the tag are deleted again when the test ends.
+func createTestRelease(t *testing.T, client *gitlab.Client, project, tag string) {
+	t.Helper()
+	if _, _, err := client.Releases.CreateRelease(project, &gitlab.CreateReleaseOptions{
+		TagName:     pointer.ToString(tag),
+		Ref:         pointer.ToString("main"),
+		Description: pointer.ToString("created by a test"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _, _ = client.Releases.DeleteRelease(project, tag)
+		_, _ = client.Tags.DeleteTag(project, tag)
+	})
+}
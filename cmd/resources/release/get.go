@@ -0,0 +1,134 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type ListOptions struct {
+	gitlabClient *gitlab.Client
+	Out          string
+	release      *gitlab.ListReleasesOptions
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	getReleasesExample = templates.Examples(`
+# list the latest releases of a project
+glctl get releases group1/devops
+
+# list every release of a project with its notes and assets as yaml
+glctl get releases group1/devops -A -o yaml`)
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		release: &gitlab.ListReleasesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 10,
+			},
+		},
+		All:       false,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+func NewGetReleasesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "releases [Project]",
+		Aliases:               []string{"release", "rel"},
+		Short:                 "List the releases of a project, latest first",
+		Example:               getReleasesExample,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Args:                  require.ExactArgs(1),
+		ValidArgsFunction:     completion.FirstArg(completion.ProjectCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{},
+	}
+	o.AddFlags(cmd)
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddPaginationVarFlags(cmd, &o.release.ListOptions)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.BoolVarP(
+		&o.All,
+		"all",
+		"A",
+		o.All,
+		"If present, list all the releases of the project, page by page.",
+	)
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && strings.TrimSpace(args[0]) == "" {
+		return fmt.Errorf("error from server (NotFound): project %s not found", args[0])
+	}
+	return nil
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	if o.All {
+		o.release.PerPage = o.ChunkSize
+		o.release.Page = 1
+	}
+	printer := cmdutil.NewReleasesPrinter(o.Out, o.ioStreams.Out)
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Release, *gitlab.Response, error) {
+			return o.gitlabClient.Releases.ListReleases(args[0], o.release, options...)
+		}, printer.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type BumpOptions struct {
+	gitlabClient *gitlab.Client
+	project      string
+	Ref          string
+	Major        bool
+	Minor        bool
+	Patch        bool
+	Auto         bool
+	Message      string
+	Release      bool
+	Force        bool
+	DryRun       bool
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	bumpTagLong = templates.LongDesc(`
+		Tag a ref with the next semantic version.
+
+		The next version follows the highest semantic version tag of the
+		project and keeps its v prefix. With --auto the bump is picked from the
+		conventional commits between that tag and the commit being tagged:
+		major for a breaking change, minor for a feat and patch for a fix or a
+		perf.
+
+		The commit the latest pipeline of the ref succeeded on is tagged. The
+		command refuses to tag when that pipeline did not succeed, unless
+		--force is given and the head of the ref is tagged.`)
+
+	bumpTagExample = templates.Examples(`
+# tag main with the next minor version, v1.4.0 after v1.3.2
+glctl tag bump -p group/myapp --ref=main --minor
+
+# pick the bump from the conventional commits since the last version and release it
+glctl tag bump -p group/myapp --ref=main --auto --release
+
+# show the next version without tagging
+glctl tag bump -p group/myapp --ref=main --auto --dry-run
+
+# tag even though the latest pipeline of main failed
+glctl tag bump -p group/myapp --ref=main --patch --force`)
+)
+
+func NewBumpOptions(ioStreams genericiooptions.IOStreams) *BumpOptions {
+	return &BumpOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewBumpTagCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewBumpOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "bump",
+		Short:                 "Tag a ref with the next semantic version",
+		Long:                  bumpTagLong,
+		Example:               bumpTagExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"semver"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *BumpOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVarP(&o.Ref, "ref", "r", o.Ref, "The branch to tag")
+	validate.VerifyMarkFlagRequired(cmd, "ref")
+	f.BoolVar(&o.Major, "major", o.Major, "Bump the major version, for breaking changes")
+	f.BoolVar(&o.Minor, "minor", o.Minor, "Bump the minor version, for new features")
+	f.BoolVar(&o.Patch, "patch", o.Patch, "Bump the patch version, for fixes")
+	f.BoolVar(&o.Auto, "auto", o.Auto, "Pick the bump from the conventional commits since the last version")
+	f.StringVarP(&o.Message, "message", "m", o.Message,
+		"Create an annotated tag with this message, a lightweight tag is created without it")
+	f.BoolVar(&o.Release, "release", o.Release,
+		"Also release the tag, with notes generated from the changelog trailers of the commits")
+	f.BoolVar(&o.Force, "force", o.Force, "Tag the ref even though its latest pipeline did not succeed")
+	f.BoolVar(&o.DryRun, "dry-run", o.DryRun, "Only print the version the ref would be tagged with")
+}
+
+// Complete completes all the required options.
+func (o *BumpOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *BumpOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if strings.TrimSpace(o.Ref) == "" {
+		return cmdutil.UsageErrorf(cmd, "--ref can not be empty")
+	}
+	bumps := 0
+	for _, b := range []bool{o.Major, o.Minor, o.Patch, o.Auto} {
+		if b {
+			bumps++
+		}
+	}
+	if bumps != 1 {
+		return cmdutil.UsageErrorf(cmd, "use exactly one of --major, --minor, --patch and --auto")
+	}
+	return nil
+}
+
+// Run executes a bump subcommand using the specified options.
+func (o *BumpOptions) Run(args []string) error {
+	previous, previousTag, found, err := o.latest()
+	if err != nil {
+		return err
+	}
+	if o.Auto && !found {
+		return fmt.Errorf("no semantic version tag in project %s to read the commits from, "+
+			"use --major, --minor or --patch for the first version", o.project)
+	}
+	sha := o.Ref
+	if !o.Force {
+		if sha, err = o.greenCommit(); err != nil {
+			return err
+		}
+	}
+	// the commit being tagged, the ref itself with --force and the commit of
+	// its latest pipeline otherwise, which may be behind the ref.
+	target := o.Ref
+	if sha != o.Ref {
+		target = fmt.Sprintf("%s at %.8s", o.Ref, sha)
+	}
+	kind := o.kind()
+	if o.Auto {
+		if kind, err = o.autoBump(previousTag, sha, target); err != nil {
+			return err
+		}
+	}
+	next := previous.bump(kind).String()
+	from := fmt.Sprintf("bumped from %s", previousTag)
+	if !found {
+		from = "the first version"
+	}
+	if o.DryRun {
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "%s would be tagged %s, %s\n", target, next, from)
+		return nil
+	}
+
+	opt := &gitlab.CreateTagOptions{TagName: pointer.ToString(next), Ref: pointer.ToString(sha)}
+	if o.Message != "" {
+		opt.Message = pointer.ToString(o.Message)
+	}
+	if _, _, err = o.gitlabClient.Tags.CreateTag(o.project, opt); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "tag %s created on %s, %s\n", next, target, from)
+	if !o.Release {
+		return nil
+	}
+	return o.release(next, previousTag)
+}
+
+func (o *BumpOptions) kind() string {
+	switch {
+	case o.Major:
+		return bumpMajor
+	case o.Minor:
+		return bumpMinor
+	}
+	return bumpPatch
+}
+
+// latest returns the highest semantic version tag of the project, or 0.0.0
+// with a v prefix when there is none.
+func (o *BumpOptions) latest() (version, string, bool, error) {
+	var names []string
+	opt := &gitlab.ListTagsOptions{ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Tag, *gitlab.Response, error) {
+			return o.gitlabClient.Tags.ListTags(o.project, opt, options...)
+		}, func(tags []*gitlab.Tag) error {
+			for _, t := range tags {
+				names = append(names, t.Name)
+			}
+			return nil
+		})
+	if err != nil {
+		return version{}, "", false, err
+	}
+	v, name, found := highestVersion(names)
+	if !found {
+		v = version{prefix: "v"}
+	}
+	return v, name, found, nil
+}
+
+// autoBump reads the commits since the tag up to sha, the commit being
+// tagged, and returns the bump they call for. target names that commit in
+// the errors.
+func (o *BumpOptions) autoBump(since, sha, target string) (string, error) {
+	var messages []string
+	opt := &gitlab.ListCommitsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize},
+		RefName:     pointer.ToString(since + ".." + sha),
+	}
+	err := cmdutil.ListPages(true, 0,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Commit, *gitlab.Response, error) {
+			return o.gitlabClient.Commits.ListCommits(o.project, opt, options...)
+		}, func(commits []*gitlab.Commit) error {
+			for _, c := range commits {
+				messages = append(messages, c.Message)
+			}
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no commit on %s since %s, nothing to release", target, since)
+	}
+	kind := conventionalBump(messages)
+	if kind == "" {
+		return "", fmt.Errorf("none of the %d commits on %s since %s is a feat, a fix or a breaking change, "+
+			"nothing to release", len(messages), target, since)
+	}
+	return kind, nil
+}
+
+// greenCommit returns the commit of the latest pipeline on the ref, as long
+// as it succeeded.
+func (o *BumpOptions) greenCommit() (string, error) {
+	pipeline, _, err := o.gitlabClient.Pipelines.GetLatestPipeline(o.project,
+		&gitlab.GetLatestPipelineOptions{Ref: pointer.ToString(o.Ref)}, cmdutil.SkipCache())
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return "", fmt.Errorf("no pipeline ran on %s in project %s, use --force to tag it anyway", o.Ref, o.project)
+	}
+	if err != nil {
+		return "", err
+	}
+	if pipeline.Status != "success" {
+		return "", fmt.Errorf("the latest pipeline #%d on %s is %s: %s\nuse --force to tag it anyway",
+			pipeline.ID, o.Ref, pipeline.Status, pipeline.WebURL)
+	}
+	return pipeline.SHA, nil
+}
+
+// release releases the tag with the notes of the commits since the previous
+// one.
+func (o *BumpOptions) release(tag, previous string) error {
+	opt := gitlab.GenerateChangelogDataOptions{
+		Version: pointer.ToString(tag),
+		To:      pointer.ToString(tag),
+	}
+	if previous != "" {
+		opt.From = pointer.ToString(previous)
+	}
+	changelog, _, err := o.gitlabClient.Repositories.GenerateChangelogData(o.project, opt)
+	if err != nil {
+		return fmt.Errorf("tag %s created but failed to generate the notes of its release: %w", tag, err)
+	}
+	_, _, err = o.gitlabClient.Releases.CreateRelease(o.project, &gitlab.CreateReleaseOptions{
+		TagName:     pointer.ToString(tag),
+		Description: pointer.ToString(changelog.Notes),
+	})
+	if err != nil {
+		return fmt.Errorf("tag %s created but failed to release it: %w", tag, err)
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "release %s created\n", tag)
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateBumpTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     []string
+		wantError error
+	}{{
+		name:  "minor",
+		flags: []string{"-p=group/myapp", "--ref=main", "--minor"},
+	}, {
+		name:  "auto",
+		flags: []string{"-p=group/myapp", "--ref=main", "--auto", "--release"},
+	}, {
+		name:      "empty ref",
+		flags:     []string{"-p=group/myapp", "--ref= ", "--patch"},
+		wantError: errors.New("--ref can not be empty\nSee 'bump -h' for help and examples"),
+	}, {
+		name:      "no bump",
+		flags:     []string{"-p=group/myapp", "--ref=main"},
+		wantError: errors.New("use exactly one of --major, --minor, --patch and --auto\nSee 'bump -h' for help and examples"),
+	}, {
+		name:      "two bumps",
+		flags:     []string{"-p=group/myapp", "--ref=main", "--major", "--auto"},
+		wantError: errors.New("use exactly one of --major, --minor, --patch and --auto\nSee 'bump -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "bump"}
+			o := NewBumpOptions(genericiooptions.NewTestIOStreamsDiscard())
+			o.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, o.Validate(cmd, nil))
+		})
+	}
+}
+
+func TestRunBumpTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      []string
+		pipeline  string
+		commits   []string
+		optsFunc  func(o *BumpOptions)
+		wantTag   string
+		wantRef   string
+		wantOut   string
+		wantError string
+	}{{
+		name:     "auto without a version tag",
+		tags:     []string{"nightly"},
+		pipeline: "success",
+		optsFunc: func(o *BumpOptions) { o.Auto = true },
+		wantError: "no semantic version tag in project group/myapp to read the commits from, " +
+			"use --major, --minor or --patch for the first version",
+	}, {
+		name:     "red pipeline",
+		tags:     []string{"v1.3.2"},
+		pipeline: "failed",
+		optsFunc: func(o *BumpOptions) { o.Patch = true },
+		wantError: "the latest pipeline #7 on main is failed: https://gitlab.example.com/pipelines/7\n" +
+			"use --force to tag it anyway",
+	}, {
+		name:      "no pipeline",
+		tags:      []string{"v1.3.2"},
+		optsFunc:  func(o *BumpOptions) { o.Patch = true },
+		wantError: "no pipeline ran on main in project group/myapp, use --force to tag it anyway",
+	}, {
+		name:     "dry run",
+		tags:     []string{"v1.2.0", "v1.3.2", "nightly"},
+		pipeline: "success",
+		optsFunc: func(o *BumpOptions) { o.Minor, o.DryRun = true, true },
+		wantOut:  "main at 4d8f2c1e would be tagged v1.4.0, bumped from v1.3.2\n",
+	}, {
+		name:     "auto and release",
+		tags:     []string{"v1.3.2"},
+		pipeline: "success",
+		commits:  []string{"fix: close the file", "feat: add tag bump"},
+		optsFunc: func(o *BumpOptions) { o.Auto, o.Release = true, true },
+		wantTag:  "v1.4.0",
+		wantRef:  "4d8f2c1e9a0b",
+		wantOut:  "tag v1.4.0 created on main at 4d8f2c1e, bumped from v1.3.2\nrelease v1.4.0 created\n",
+	}, {
+		name:      "auto without a feat or a fix",
+		tags:      []string{"v1.3.2"},
+		pipeline:  "success",
+		commits:   []string{"docs: explain bump", "chore: update deps"},
+		optsFunc:  func(o *BumpOptions) { o.Auto = true },
+		wantError: "none of the 2 commits on main at 4d8f2c1e since v1.3.2 is a feat, a fix or a breaking change, nothing to release",
+	}, {
+		name:     "first version of a red ref with force",
+		pipeline: "failed",
+		optsFunc: func(o *BumpOptions) { o.Patch, o.Force = true, true },
+		wantTag:  "v0.0.1",
+		wantRef:  "main",
+		wantOut:  "tag v0.0.1 created on main, the first version\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var created, createdRef, commitRange string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				var body any
+				switch path := r.URL.Path; {
+				case strings.HasSuffix(path, "/repository/tags") && r.Method == http.MethodPost:
+					var opt gitlab.CreateTagOptions
+					if err := json.NewDecoder(r.Body).Decode(&opt); err != nil {
+						t.Error(err)
+					}
+					created, createdRef = *opt.TagName, *opt.Ref
+					body = map[string]any{"name": created}
+				case strings.HasSuffix(path, "/repository/tags"):
+					var tags []map[string]any
+					for _, name := range tc.tags {
+						tags = append(tags, map[string]any{"name": name})
+					}
+					body = tags
+				case strings.HasSuffix(path, "/pipelines/latest"):
+					if tc.pipeline == "" {
+						w.WriteHeader(http.StatusNotFound)
+						body = map[string]any{"message": "404 Not found"}
+						break
+					}
+					body = map[string]any{"id": 7, "status": tc.pipeline, "sha": "4d8f2c1e9a0b",
+						"web_url": "https://gitlab.example.com/pipelines/7"}
+				case strings.HasSuffix(path, "/repository/commits"):
+					commitRange = r.URL.Query().Get("ref_name")
+					var commits []map[string]any
+					for _, message := range tc.commits {
+						commits = append(commits, map[string]any{"message": message})
+					}
+					body = commits
+				case strings.HasSuffix(path, "/repository/changelog"):
+					body = map[string]any{"notes": "## 1.4.0\n"}
+				case strings.HasSuffix(path, "/releases"):
+					w.WriteHeader(http.StatusCreated)
+					body = map[string]any{"tag_name": created}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					body = map[string]any{"message": "404 Not found"}
+				}
+				_ = json.NewEncoder(w).Encode(body)
+			}))
+			defer srv.Close()
+			client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+			assert.NoError(t, err)
+
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			o := NewBumpOptions(streams)
+			o.gitlabClient, o.project, o.Ref = client, "group/myapp", "main"
+			tc.optsFunc(o)
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = o.Run(nil)
+			})
+			if tc.wantError != "" {
+				assert.EqualError(t, err, tc.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantTag, created)
+			if tc.wantTag != "" {
+				assert.Equal(t, tc.wantRef, createdRef)
+			}
+			if tc.commits != nil {
+				// the commits are read up to the commit being tagged.
+				assert.Equal(t, "v1.3.2..4d8f2c1e9a0b", commitRange)
+			}
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// conventionalHeader matches the first line of a conventional commit,
+	// type(scope)!: description.
+	conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\([^)]*\))?(!?):\s`)
+
+	// breakingFooter matches the footer announcing a breaking change.
+	breakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s`)
+)
+
+// conventionalBump returns the bump the conventional commit messages call
+// for: major for a breaking change, minor for a feature and patch for a fix
+// or a performance improvement. It is empty when none of the commits has
+// to be released, like documentation or chores.
+func conventionalBump(messages []string) string {
+	bump := ""
+	for _, message := range messages {
+		header, _, _ := strings.Cut(message, "\n")
+		m := conventionalHeader.FindStringSubmatch(header)
+		if m == nil {
+			continue
+		}
+		if m[2] == "!" || breakingFooter.MatchString(message) {
+			return bumpMajor
+		}
+		switch strings.ToLower(m[1]) {
+		case "feat":
+			bump = bumpMinor
+		case "fix", "perf":
+			if bump == "" {
+				bump = bumpPatch
+			}
+		}
+	}
+	return bump
+}
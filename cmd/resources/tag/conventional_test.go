@@ -0,0 +1,60 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConventionalBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     string
+	}{{
+		name:     "fix",
+		messages: []string{"fix: handle empty pages\n", "docs: typo"},
+		want:     bumpPatch,
+	}, {
+		name:     "feature with a scope",
+		messages: []string{"fix(api): retry", "feat(cli): add tag bump"},
+		want:     bumpMinor,
+	}, {
+		name:     "breaking change marker",
+		messages: []string{"feat: add x", "refactor(config)!: rename the settings"},
+		want:     bumpMajor,
+	}, {
+		name:     "breaking change footer",
+		messages: []string{"feat: new format\n\nBREAKING CHANGE: the old format is no longer read\n"},
+		want:     bumpMajor,
+	}, {
+		name:     "footer of a commit that is not conventional",
+		messages: []string{"Merge branch 'main'\n\nBREAKING CHANGE: quoted"},
+	}, {
+		name:     "nothing to release",
+		messages: []string{"docs: readme", "chore(deps): bump x", "Update README.md"},
+	}, {
+		name:     "upper case type",
+		messages: []string{"Perf: cache the tags"},
+		want:     bumpPatch,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, conventionalBump(tc.messages))
+		})
+	}
+}
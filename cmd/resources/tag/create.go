@@ -0,0 +1,131 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type CreateOptions struct {
+	gitlabClient *gitlab.Client
+	tag          *gitlab.CreateTagOptions
+	project      string
+	Ref          string
+	Message      string
+	Out          string
+	ioStreams    genericiooptions.IOStreams
+}
+
+var (
+	createTagExample = templates.Examples(`
+# create a lightweight tag v1.2.0 on the main branch of project group/myapp
+glctl create tag v1.2.0 --project=group/myapp --ref=main
+
+# create an annotated tag on a commit
+glctl create tag v1.2.0 -p group/myapp --ref=4f2a9c1 -m "First stable release"`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams: ioStreams,
+		tag:       &gitlab.CreateTagOptions{},
+		Out:       "simple",
+	}
+}
+
+func NewCreateTagCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "tag",
+		Aliases:               []string{"t"},
+		Short:                 "Create a tag from a branch or a commit",
+		Example:               createTagExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.StringVarP(&o.Ref, "ref", "r", o.Ref, "The branch name or commit SHA to create the tag from")
+	validate.VerifyMarkFlagRequired(cmd, "ref")
+	f.StringVarP(&o.Message, "message", "m", o.Message,
+		"Create an annotated tag with this message, a lightweight tag is created without it")
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.tag.TagName = pointer.ToString(args[0])
+	o.tag.Ref = pointer.ToString(o.Ref)
+	if o.Message != "" {
+		o.tag.Message = pointer.ToString(o.Message)
+	}
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the name of the tag can not be empty")
+	}
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if strings.TrimSpace(o.Ref) == "" {
+		return cmdutil.UsageErrorf(cmd, "--ref can not be empty")
+	}
+	return nil
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	tag, _, err := o.gitlabClient.Tags.CreateTag(o.project, o.tag)
+	if err != nil {
+		return err
+	}
+	return cmdutil.PrintTagsOut(o.Out, o.ioStreams.Out, tag)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreateTag(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "empty name",
+		args:      []string{" "},
+		flags:     []string{"-p=" + project, "--ref=main"},
+		wantError: errors.New("the name of the tag can not be empty\nSee 'tag -h' for help and examples"),
+	}, {
+		name:      "empty project",
+		args:      []string{"glctl-create-tag"},
+		flags:     []string{"-p= ", "--ref=main"},
+		wantError: errors.New("--project can not be empty\nSee 'tag -h' for help and examples"),
+	}, {
+		name:      "empty ref",
+		args:      []string{"glctl-create-tag"},
+		flags:     []string{"-p=" + project, "--ref= "},
+		wantError: errors.New("--ref can not be empty\nSee 'tag -h' for help and examples"),
+	}, {
+		name:    "create a lightweight tag",
+		args:    []string{"glctl-create-tag"},
+		flags:   []string{"-p=" + project, "--ref=main"},
+		wantOut: "glctl-create-tag",
+	}, {
+		name:    "create an annotated tag",
+		args:    []string{"glctl-create-annotated-tag"},
+		flags:   []string{"-p=" + project, "--ref=main", "-m=created by a test", "--out=json"},
+		wantOut: `"message": "created by a test"`,
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "tag"}
+			cmdOptions := NewCreateOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() {
+				_, _ = cmdOptions.gitlabClient.Tags.DeleteTag(project, tc.args[0])
+			})
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, out, tc.wantOut)
+		})
+	}
+}
+
+// createTestTag tags main of project, the tag is deleted again when the test
+// ends.
+func createTestTag(t *testing.T, client *gitlab.Client, project, name string) {
+	t.Helper()
+	if _, _, err := client.Tags.CreateTag(project, &gitlab.CreateTagOptions{
+		TagName: pointer.ToString(name),
+		Ref:     pointer.ToString("main"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = client.Tags.DeleteTag(project, name)
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestDeleteTag(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createTestTag(t, client, project, "glctl-delete-tag")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "empty name",
+		args:      []string{""},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("the name of the tag can not be empty\nSee 'tag -h' for help and examples"),
+	}, {
+		name:      "empty project",
+		args:      []string{"glctl-delete-tag"},
+		flags:     []string{"-p= "},
+		wantError: errors.New("--project can not be empty\nSee 'tag -h' for help and examples"),
+	}, {
+		name:    "delete a tag",
+		args:    []string{"glctl-delete-tag"},
+		flags:   []string{"-p=" + project},
+		wantOut: "tag glctl-delete-tag deleted from project " + project + "\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "tag"}
+			cmdOptions := NewDeleteOptions(streams)
+			cmdutil.AddProjectVarPFlag(cmd, &cmdOptions.project)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+	_, _, err = client.Tags.GetTag(project, "glctl-delete-tag")
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+type EditOptions struct {
+	gitlabClient      *gitlab.Client
+	project           string
+	Protect           bool
+	Unprotect         bool
+	CreateAccessLevel string
+	ioStreams         genericiooptions.IOStreams
+}
+
+var (
+	editTagExample = templates.Examples(`
+# only let maintainers create the tags matching v*
+glctl edit tag 'v*' -p group/myapp --protect
+
+# let developers create the release candidate tags
+glctl edit tag 'v*-rc*' -p group/myapp --protect --create-access-level=developer
+
+# remove the protection
+glctl edit tag 'v*' -p group/myapp --unprotect`)
+
+	// createAccessLevels are the roles allowed to create a protected tag.
+	createAccessLevels = map[string]gitlab.AccessLevelValue{
+		"no-one":     gitlab.NoPermissions,
+		"developer":  gitlab.DeveloperPermissions,
+		"maintainer": gitlab.MaintainerPermissions,
+	}
+)
+
+func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
+	return &EditOptions{
+		ioStreams:         ioStreams,
+		CreateAccessLevel: "maintainer",
+	}
+}
+
+func NewEditTagCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewEditOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "tag",
+		Aliases:               []string{"t"},
+		Short:                 "Protect or unprotect the tags matching a name or a wildcard",
+		Example:               editTagExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		ValidArgsFunction:     completion.FirstArg(completion.TagCompletionFunc(f)),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("create-access-level",
+		cobra.FixedCompletions(accessLevelNames(), cobra.ShellCompDirectiveNoFileComp)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *EditOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.project)
+	validate.VerifyMarkFlagRequired(cmd, "project")
+	f := cmd.Flags()
+	f.BoolVar(&o.Protect, "protect", o.Protect, "Protect the tags matching the name")
+	f.BoolVar(&o.Unprotect, "unprotect", o.Unprotect, "Remove the protection of the tags matching the name")
+	f.StringVar(&o.CreateAccessLevel, "create-access-level", o.CreateAccessLevel,
+		fmt.Sprintf("Used with '--protect'. The role allowed to create the tags (%s)",
+			strings.Join(accessLevelNames(), ", ")))
+}
+
+// Complete completes all the required options.
+func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(args[0]) == "" {
+		return cmdutil.UsageErrorf(cmd, "the name of the tag can not be empty")
+	}
+	if strings.TrimSpace(o.project) == "" {
+		return cmdutil.UsageErrorf(cmd, "--project can not be empty")
+	}
+	if o.Protect == o.Unprotect {
+		return cmdutil.UsageErrorf(cmd, "use exactly one of --protect and --unprotect")
+	}
+	if cmd.Flags().Changed("create-access-level") && !o.Protect {
+		return cmdutil.UsageErrorf(cmd, "--create-access-level can only be used with --protect")
+	}
+	return validate.ValidateFlagStringValue(accessLevelNames(), cmd, "create-access-level")
+}
+
+// Run executes an edit subcommand using the specified options.
+func (o *EditOptions) Run(args []string) error {
+	if o.Unprotect {
+		if _, err := o.gitlabClient.ProtectedTags.UnprotectRepositoryTags(o.project, args[0]); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(o.ioStreams.Out, "tag %s unprotected\n", args[0])
+		return nil
+	}
+	_, _, err := o.gitlabClient.ProtectedTags.ProtectRepositoryTags(o.project, &gitlab.ProtectRepositoryTagsOptions{
+		Name:              pointer.ToString(args[0]),
+		CreateAccessLevel: pointer.To(createAccessLevels[o.CreateAccessLevel]),
+	})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "tag %s protected, %s can create it\n", args[0], o.CreateAccessLevel)
+	return nil
+}
+
+func accessLevelNames() []string {
+	names := make([]string, 0, len(createAccessLevels))
+	for name := range createAccessLevels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
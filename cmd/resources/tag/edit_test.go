@@ -0,0 +1,77 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestEditTagValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		wantError error
+	}{{
+		name:  "protect",
+		flags: map[string]string{"protect": "true"},
+	}, {
+		name:  "protect for developers",
+		flags: map[string]string{"protect": "true", "create-access-level": "developer"},
+	}, {
+		name:  "unprotect",
+		flags: map[string]string{"unprotect": "true"},
+	}, {
+		name:      "neither protect nor unprotect",
+		wantError: errors.New("use exactly one of --protect and --unprotect\nSee ' -h' for help and examples"),
+	}, {
+		name:      "both protect and unprotect",
+		flags:     map[string]string{"protect": "true", "unprotect": "true"},
+		wantError: errors.New("use exactly one of --protect and --unprotect\nSee ' -h' for help and examples"),
+	}, {
+		name:  "access level without protect",
+		flags: map[string]string{"unprotect": "true", "create-access-level": "developer"},
+		wantError: errors.New(
+			"--create-access-level can only be used with --protect\nSee ' -h' for help and examples"),
+	}, {
+		name:  "unknown access level",
+		flags: map[string]string{"protect": "true", "create-access-level": "owner"},
+		wantError: errors.New(
+			"'owner' is not a recognized value of 'create-access-level' flag; choose from [developer, maintainer, no-one]"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			o := NewEditOptions(genericiooptions.NewTestIOStreamsDiscard())
+			o.AddFlags(cmd)
+			o.project = "group/myapp"
+			for name, value := range tc.flags {
+				assert.NoError(t, cmd.Flags().Set(name, value))
+			}
+			err := o.Validate(cmd, []string{"v*"})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of version bumps, from the least to the most significant.
+const (
+	bumpPatch = "patch"
+	bumpMinor = "minor"
+	bumpMajor = "major"
+)
+
+// semverTag matches the semantic version tags, with an optional v prefix.
+var semverTag = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// version is a semantic version parsed from a tag name.
+type version struct {
+	prefix     string
+	major      int64
+	minor      int64
+	patch      int64
+	prerelease string
+}
+
+// parseVersion parses a tag named after a semantic version, like v1.2.3 or
+// 1.2.3-rc.1, build metadata is dropped.
+func parseVersion(name string) (version, bool) {
+	m := semverTag.FindStringSubmatch(name)
+	if m == nil {
+		return version{}, false
+	}
+	v := version{prefix: m[1], prerelease: m[5]}
+	var err error
+	for i, n := range []*int64{&v.major, &v.minor, &v.patch} {
+		if *n, err = strconv.ParseInt(m[i+2], 10, 64); err != nil {
+			return version{}, false
+		}
+	}
+	return v, true
+}
+
+func (v version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.prefix, v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	return s
+}
+
+// less reports whether v precedes w, a pre-release precedes its release.
+func (v version) less(w version) bool {
+	switch {
+	case v.major != w.major:
+		return v.major < w.major
+	case v.minor != w.minor:
+		return v.minor < w.minor
+	case v.patch != w.patch:
+		return v.patch < w.patch
+	case v.prerelease == "" || w.prerelease == "":
+		return v.prerelease != "" && w.prerelease == ""
+	}
+	return lessPrerelease(v.prerelease, w.prerelease)
+}
+
+// lessPrerelease compares pre-releases identifier by identifier, numeric
+// identifiers are compared as numbers and precede the alphanumeric ones.
+func lessPrerelease(a, b string) bool {
+	x, y := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] == y[i] {
+			continue
+		}
+		m, errX := strconv.ParseInt(x[i], 10, 64)
+		n, errY := strconv.ParseInt(y[i], 10, 64)
+		switch {
+		case errX == nil && errY == nil:
+			return m < n
+		case errX == nil || errY == nil:
+			return errX == nil
+		}
+		return x[i] < y[i]
+	}
+	return len(x) < len(y)
+}
+
+// bump returns the next release of the given kind, the release of a
+// pre-release is the version it prepares.
+func (v version) bump(kind string) version {
+	next := version{prefix: v.prefix, major: v.major, minor: v.minor, patch: v.patch}
+	switch kind {
+	case bumpMajor:
+		if v.prerelease == "" || v.minor != 0 || v.patch != 0 {
+			next.major, next.minor, next.patch = v.major+1, 0, 0
+		}
+	case bumpMinor:
+		if v.prerelease == "" || v.patch != 0 {
+			next.minor, next.patch = v.minor+1, 0
+		}
+	default:
+		if v.prerelease == "" {
+			next.patch = v.patch + 1
+		}
+	}
+	return next
+}
+
+// highestVersion returns the highest semantic version among the tag names,
+// the other tags are ignored.
+func highestVersion(names []string) (version, string, bool) {
+	var (
+		highest version
+		tag     string
+		found   bool
+	)
+	for _, name := range names {
+		v, ok := parseVersion(name)
+		if !ok {
+			continue
+		}
+		if !found || highest.less(v) {
+			highest, tag, found = v, name, true
+		}
+	}
+	return highest, tag, found
+}
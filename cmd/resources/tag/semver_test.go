@@ -0,0 +1,93 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		want version
+		ok   bool
+	}{
+		{name: "v1.2.3", want: version{prefix: "v", major: 1, minor: 2, patch: 3}, ok: true},
+		{name: "10.0.12", want: version{major: 10, patch: 12}, ok: true},
+		{name: "v2.0.0-rc.1", want: version{prefix: "v", major: 2, prerelease: "rc.1"}, ok: true},
+		{name: "1.0.0+build.5", want: version{major: 1}, ok: true},
+		{name: "v1.2"},
+		{name: "v01.2.3"},
+		{name: "release-1.2.3"},
+		{name: "latest"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseVersion(tc.name)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11",
+		"1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		v, _ := parseVersion(ordered[i])
+		w, _ := parseVersion(ordered[i+1])
+		assert.Truef(t, v.less(w), "%s should precede %s", ordered[i], ordered[i+1])
+		assert.Falsef(t, w.less(v), "%s should not precede %s", ordered[i+1], ordered[i])
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	tests := []struct {
+		version string
+		kind    string
+		want    string
+	}{
+		{version: "v1.2.3", kind: bumpPatch, want: "v1.2.4"},
+		{version: "v1.2.3", kind: bumpMinor, want: "v1.3.0"},
+		{version: "v1.2.3", kind: bumpMajor, want: "v2.0.0"},
+		{version: "0.9.9", kind: bumpMinor, want: "0.10.0"},
+		{version: "v2.0.0-rc.1", kind: bumpPatch, want: "v2.0.0"},
+		{version: "v2.0.0-rc.1", kind: bumpMajor, want: "v2.0.0"},
+		{version: "v1.3.0-rc.1", kind: bumpMinor, want: "v1.3.0"},
+		{version: "v1.3.0-rc.1", kind: bumpMajor, want: "v2.0.0"},
+		{version: "v1.3.1-rc.1", kind: bumpMinor, want: "v1.4.0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.version+" "+tc.kind, func(t *testing.T) {
+			v, ok := parseVersion(tc.version)
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, v.bump(tc.kind).String())
+		})
+	}
+}
+
+func TestHighestVersion(t *testing.T) {
+	v, tag, found := highestVersion([]string{"v1.9.0", "nightly", "v1.10.0-rc.1", "v1.10.0", "v1.2.0"})
+	assert.True(t, found)
+	assert.Equal(t, "v1.10.0", tag)
+	assert.Equal(t, version{prefix: "v", major: 1, minor: 10}, v)
+
+	_, _, found = highestVersion([]string{"nightly", "stable"})
+	assert.False(t, found)
+}
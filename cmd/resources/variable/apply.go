@@ -0,0 +1,192 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type ApplyOptions struct {
+	target
+	cmdutil.ApplyFlags
+	desired   []*Variable
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	applyVariablesExample = templates.Examples(`
+# create and update the variables of a project to match vars.yaml
+glctl apply variables -f vars.yaml -p group/myapp
+
+# also delete the variables missing from vars.yaml, after checking the changes
+glctl apply variables -f vars.yaml -p group/myapp --prune --dry-run
+glctl apply variables -f vars.yaml -p group/myapp --prune`)
+)
+
+func NewApplyOptions(ioStreams genericiooptions.IOStreams) *ApplyOptions {
+	return &ApplyOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewApplyVariablesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplyOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variables",
+		Aliases:               []string{"variable", "var", "vars"},
+		Short:                 "Make the CI/CD variables of a project, a group or the instance match a file",
+		Example:               applyVariablesExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.target.addFlags(cmd)
+	cmdutil.AddApplyVarFlags(cmd, &o.ApplyFlags,
+		"The yaml file listing the variables, in the format written by export variables", "delete the variables")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *ApplyOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.desired, err = cmdutil.ReadApplyFile[*Variable](o.File, "variable")
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ApplyOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd, ""); err != nil {
+		return err
+	}
+	return normalize(o.desired, o.defaultScope(), o.File)
+}
+
+// Run executes an apply subcommand using the specified options.
+func (o *ApplyOptions) Run(args []string) error {
+	current, err := o.list()
+	if err != nil {
+		return err
+	}
+	return o.applier().Run(o.ioStreams.Out, current, o.desired, o.ApplyFlags)
+}
+
+// applier applies the changes of the variables to the target.
+func (o *ApplyOptions) applier() *cmdutil.Applier[*Variable] {
+	return &cmdutil.Applier[*Variable]{
+		Kind:   "variable",
+		Target: o.target.String(),
+		ID:     (*Variable).id,
+		Diff: func(old, v *Variable) []string {
+			return changedFields(old, knownValue(old, v))
+		},
+		Create: o.create,
+		Update: func(old, v *Variable) error {
+			return o.update(old, knownValue(old, v))
+		},
+		Delete: func(old *Variable) error {
+			return o.remove(old.Key, old.EnvironmentScope)
+		},
+	}
+}
+
+// normalize fills in the defaults of the variables read from file and
+// rejects the ones the server would refuse or that are given twice.
+func normalize(variables []*Variable, scope, file string) error {
+	seen := map[string]bool{}
+	for i, v := range variables {
+		if strings.TrimSpace(v.Key) == "" {
+			return fmt.Errorf("variable %d of %s has no key", i+1, file)
+		}
+		if v.Type == "" {
+			v.Type = variableTypes[0]
+		}
+		if v.Type != variableTypes[0] && v.Type != variableTypes[1] {
+			return fmt.Errorf("variable %s of %s has the type %q, choose from [%s]",
+				v.Key, file, v.Type, strings.Join(variableTypes, ", "))
+		}
+		switch {
+		case v.EnvironmentScope == "":
+			v.EnvironmentScope = scope
+		case scope == "":
+			return fmt.Errorf("variable %s of %s has an environment scope, which instance variables do not have",
+				v.Key, file)
+		}
+		if seen[v.id()] {
+			return fmt.Errorf("variable %s is given twice in %s", v.id(), file)
+		}
+		seen[v.id()] = true
+	}
+	return nil
+}
+
+// knownValue returns v with the value of old when old is hidden, the
+// unknown value of a hidden variable is kept as it is.
+func knownValue(old, v *Variable) *Variable {
+	if !old.Hidden {
+		return v
+	}
+	known := *v
+	known.Value = old.Value
+	return &known
+}
+
+// changedFields names the attributes of old which differ in v, values are
+// never shown.
+func changedFields(old, v *Variable) []string {
+	var fields []string
+	if old.Value != v.Value {
+		fields = append(fields, "value")
+	}
+	if old.Type != v.Type {
+		fields = append(fields, "type="+v.Type)
+	}
+	for _, f := range []struct {
+		name     string
+		old, new bool
+	}{
+		{"protected", old.Protected, v.Protected},
+		{"masked", old.Masked, v.Masked},
+		{"raw", old.Raw, v.Raw},
+	} {
+		if f.old != f.new {
+			fields = append(fields, f.name+"="+strconv.FormatBool(f.new))
+		}
+	}
+	if old.Description != v.Description {
+		fields = append(fields, "description")
+	}
+	return fields
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestDiffVariable(t *testing.T) {
+	diff := (&ApplyOptions{}).applier().Diff
+	tests := []struct {
+		name    string
+		current *Variable
+		desired *Variable
+		want    []string
+	}{{
+		name:    "value and type",
+		current: &Variable{Key: "LOG_LEVEL", Value: "debug", Type: "env_var"},
+		desired: &Variable{Key: "LOG_LEVEL", Value: "info", Type: "file"},
+		want:    []string{"value", "type=file"},
+	}, {
+		name:    "attributes",
+		current: &Variable{Key: "DB_PASSWORD", Value: "s3cret", Protected: true, Masked: true},
+		desired: &Variable{Key: "DB_PASSWORD", Value: "s3cret", Masked: true, Raw: true, Description: "db"},
+		want:    []string{"protected=false", "raw=true", "description"},
+	}, {
+		name:    "unknown value of a hidden variable",
+		current: &Variable{Key: "TOKEN", Masked: true, Hidden: true},
+		desired: &Variable{Key: "TOKEN", Value: "unknown", Masked: true},
+	}, {
+		name:    "up to date",
+		current: &Variable{Key: "API_URL", Value: "https://api"},
+		desired: &Variable{Key: "API_URL", Value: "https://api"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, diff(tc.current, tc.desired))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables []*Variable
+		scope     string
+		want      []*Variable
+		wantError error
+	}{{
+		name:      "defaults",
+		variables: []*Variable{{Key: "A", Value: "1"}, {Key: "A", Value: "2", EnvironmentScope: "production"}},
+		scope:     "*",
+		want: []*Variable{
+			{Key: "A", Value: "1", Type: "env_var", EnvironmentScope: "*"},
+			{Key: "A", Value: "2", Type: "env_var", EnvironmentScope: "production"},
+		},
+	}, {
+		name:      "given twice",
+		variables: []*Variable{{Key: "A"}, {Key: "A", EnvironmentScope: "*"}},
+		scope:     "*",
+		wantError: errors.New("variable A is given twice in vars.yaml"),
+	}, {
+		name:      "missing key",
+		variables: []*Variable{{Key: "A"}, {Value: "1"}},
+		scope:     "*",
+		wantError: errors.New("variable 2 of vars.yaml has no key"),
+	}, {
+		name:      "unknown type",
+		variables: []*Variable{{Key: "A", Type: "secret"}},
+		scope:     "*",
+		wantError: errors.New(`variable A of vars.yaml has the type "secret", choose from [env_var, file]`),
+	}, {
+		name:      "scope of an instance variable",
+		variables: []*Variable{{Key: "A", EnvironmentScope: "production"}},
+		wantError: errors.New("variable A of vars.yaml has an environment scope, which instance variables do not have"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := normalize(tc.variables, tc.scope, "vars.yaml")
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, tc.variables)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+// editVariableFlags are the flags setting an attribute of a variable.
+var editVariableFlags = []string{"value", "value-file", "type", "protected", "masked", "raw", "description"}
+
+type CreateOptions struct {
+	target
+	variable  Variable
+	Scope     string
+	ValueFile string
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	createVariableExample = templates.Examples(`
+# create a variable of a project
+glctl create variable LOG_LEVEL --value=debug -p group/myapp
+
+# create a protected and masked variable for the production environment
+glctl create variable DATABASE_PASSWORD --value=s3cret --scope=production --protected --masked -p group/myapp
+
+# create a file variable of a group from a local file
+glctl create variable KUBECONFIG --value-file=./kubeconfig --type=file -G group`)
+)
+
+func NewCreateOptions(ioStreams genericiooptions.IOStreams) *CreateOptions {
+	return &CreateOptions{
+		ioStreams: ioStreams,
+		variable:  Variable{Type: variableTypes[0]},
+	}
+}
+
+func NewCreateVariableCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variable",
+		Aliases:               []string{"var"},
+		Short:                 "Create a CI/CD variable of a project, a group or the instance",
+		Example:               createVariableExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"variables"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *CreateOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	addAttributeFlags(cmd, &o.variable, &o.ValueFile)
+	cmd.Flags().StringVar(&o.Scope, "scope", o.Scope,
+		"The environment scope of the variable, all the environments by default")
+}
+
+// addAttributeFlags registers the flags setting the attributes of v.
+func addAttributeFlags(cmd *cobra.Command, v *Variable, valueFile *string) {
+	f := cmd.Flags()
+	f.StringVar(&v.Value, "value", v.Value, "The value of the variable")
+	f.StringVar(valueFile, "value-file", *valueFile, "Read the value of the variable from this file")
+	f.StringVar(&v.Type, "type", v.Type,
+		fmt.Sprintf("The type of the variable (%s)", strings.Join(variableTypes, ", ")))
+	f.BoolVar(&v.Protected, "protected", v.Protected, "Only expose the variable to protected branches and tags")
+	f.BoolVar(&v.Masked, "masked", v.Masked, "Mask the value of the variable in job logs")
+	f.BoolVar(&v.Raw, "raw", v.Raw, "Do not expand other variables referenced in the value")
+	f.StringVar(&v.Description, "description", v.Description, "The description of the variable")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("type",
+		cobra.FixedCompletions(variableTypes, cobra.ShellCompDirectiveNoFileComp)))
+}
+
+// Complete completes all the required options.
+func (o *CreateOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.variable.Key = args[0]
+	o.variable.EnvironmentScope = o.Scope
+	if o.Scope == "" {
+		o.variable.EnvironmentScope = o.defaultScope()
+	}
+	return readValueFile(&o.variable, o.ValueFile)
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *CreateOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd, o.Scope); err != nil {
+		return err
+	}
+	if strings.TrimSpace(o.variable.Key) == "" {
+		return cmdutil.UsageErrorf(cmd, "the key of the variable can not be empty")
+	}
+	if !cmd.Flags().Changed("value") && o.ValueFile == "" {
+		return cmdutil.UsageErrorf(cmd, "one of --value or --value-file is required")
+	}
+	return validateAttributes(cmd)
+}
+
+// Run executes a create subcommand using the specified options.
+func (o *CreateOptions) Run(args []string) error {
+	if err := o.create(&o.variable); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "variable %s created in %s\n", o.variable.id(), o.target.String())
+	return nil
+}
+
+// readValueFile sets the value of v to the content of the file name, if any.
+func readValueFile(v *Variable, name string) error {
+	if name == "" {
+		return nil
+	}
+	b, err := cmdutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	v.Value = string(b)
+	return nil
+}
+
+// validateAttributes validates the flags added by addAttributeFlags.
+func validateAttributes(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("value") && cmd.Flags().Changed("value-file") {
+		return cmdutil.UsageErrorf(cmd, "--value can not be combined with --value-file")
+	}
+	if !cmd.Flags().Changed("type") {
+		return nil
+	}
+	return validate.ValidateFlagStringValue(variableTypes, cmd, "type")
+}
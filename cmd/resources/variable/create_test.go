@@ -0,0 +1,140 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestCreateVariable(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	valueFile := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(valueFile, []byte("apiVersion: v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:      "no target",
+		args:      []string{"GLCTL_CREATE"},
+		flags:     []string{"--value=debug"},
+		wantError: errors.New("one of --project, --group or --instance is required\nSee 'variable -h' for help and examples"),
+	}, {
+		name:  "several targets",
+		args:  []string{"GLCTL_CREATE"},
+		flags: []string{"-p=" + project, "--instance", "--value=debug"},
+		wantError: errors.New("only one of --project, --group and --instance can be used\n" +
+			"See 'variable -h' for help and examples"),
+	}, {
+		name:      "scope of an instance variable",
+		args:      []string{"GLCTL_CREATE"},
+		flags:     []string{"--instance", "--scope=production", "--value=debug"},
+		wantError: errors.New("instance variables have no environment scope\nSee 'variable -h' for help and examples"),
+	}, {
+		name:      "empty key",
+		args:      []string{" "},
+		flags:     []string{"-p=" + project, "--value=debug"},
+		wantError: errors.New("the key of the variable can not be empty\nSee 'variable -h' for help and examples"),
+	}, {
+		name:      "no value",
+		args:      []string{"GLCTL_CREATE"},
+		flags:     []string{"-p=" + project},
+		wantError: errors.New("one of --value or --value-file is required\nSee 'variable -h' for help and examples"),
+	}, {
+		name:      "value and value file",
+		args:      []string{"GLCTL_CREATE"},
+		flags:     []string{"-p=" + project, "--value=debug", "--value-file=" + valueFile},
+		wantError: errors.New("--value can not be combined with --value-file\nSee 'variable -h' for help and examples"),
+	}, {
+		name:      "unknown type",
+		args:      []string{"GLCTL_CREATE"},
+		flags:     []string{"-p=" + project, "--value=debug", "--type=secret"},
+		wantError: errors.New("'secret' is not a recognized value of 'type' flag; choose from [env_var, file]"),
+	}, {
+		name:    "create a variable of a project",
+		args:    []string{"GLCTL_CREATE"},
+		flags:   []string{"-p=" + project, "--value=debug", "--protected", "--description=created by a test"},
+		wantOut: "variable GLCTL_CREATE created in project " + project + "\n",
+	}, {
+		name:    "create a file variable of an environment",
+		args:    []string{"GLCTL_CREATE"},
+		flags:   []string{"-p=" + project, "--value-file=" + valueFile, "--type=file", "--scope=production"},
+		wantOut: "variable GLCTL_CREATE (production) created in project " + project + "\n",
+	}}
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "variable"}
+			cmdOptions := NewCreateOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() {
+				_ = cmdOptions.remove(cmdOptions.variable.Key, cmdOptions.variable.EnvironmentScope)
+			})
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(tc.args)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+}
+
+// createTestVariable creates a variable of project for all the environments,
+// it is removed again when the test ends.
+func createTestVariable(t *testing.T, client *gitlab.Client, project, key string) {
+	t.Helper()
+	if _, _, err := client.ProjectVariables.CreateVariable(project, &gitlab.CreateProjectVariableOptions{
+		Key:   pointer.ToString(key),
+		Value: pointer.ToString("debug"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = client.ProjectVariables.RemoveVariable(project, key, &gitlab.RemoveProjectVariableOptions{
+			Filter: &gitlab.VariableFilter{EnvironmentScope: allEnvironments},
+		})
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type DeleteOptions struct {
+	target
+	key       string
+	Scope     string
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	deleteVariableExample = templates.Examples(`
+# delete a variable of a project
+glctl delete variable LOG_LEVEL -p group/myapp
+
+# delete the variable of the staging environment only
+glctl delete variable DATABASE_URL --scope=staging -p group/myapp`)
+)
+
+func NewDeleteOptions(ioStreams genericiooptions.IOStreams) *DeleteOptions {
+	return &DeleteOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewDeleteVariableCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDeleteOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variable",
+		Aliases:               []string{"var"},
+		Short:                 "Delete a CI/CD variable of a project, a group or the instance",
+		Example:               deleteVariableExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+		SuggestFor: []string{"variables"},
+	}
+	o.target.addFlags(cmd)
+	cmd.Flags().StringVar(&o.Scope, "scope", o.Scope,
+		"The environment scope of the variable to delete, all the environments by default")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *DeleteOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.key = args[0]
+	return nil
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *DeleteOptions) Validate(cmd *cobra.Command, args []string) error {
+	return o.target.validate(cmd, o.Scope)
+}
+
+// Run executes a delete subcommand using the specified options.
+func (o *DeleteOptions) Run(args []string) error {
+	v := &Variable{Key: o.key, EnvironmentScope: o.Scope}
+	if v.EnvironmentScope == "" {
+		v.EnvironmentScope = o.defaultScope()
+	}
+	if err := o.remove(v.Key, v.EnvironmentScope); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "variable %s deleted from %s\n", v.id(), o.target.String())
+	return nil
+}
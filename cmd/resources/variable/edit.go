@@ -0,0 +1,150 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+type EditOptions struct {
+	target
+	key       string
+	changes   Variable
+	Scope     string
+	ValueFile string
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	editVariableExample = templates.Examples(`
+# change the value of a variable of a project
+glctl edit variable LOG_LEVEL --value=info -p group/myapp
+
+# protect the variable of the production environment
+glctl edit variable DATABASE_PASSWORD --scope=production --protected -p group/myapp
+
+# stop masking a variable of the instance
+glctl edit variable PROXY --masked=false --instance`)
+)
+
+func NewEditOptions(ioStreams genericiooptions.IOStreams) *EditOptions {
+	return &EditOptions{
+		ioStreams: ioStreams,
+	}
+}
+
+func NewEditVariableCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewEditOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variable",
+		Aliases:               []string{"var"},
+		Short:                 "Edit a CI/CD variable of a project, a group or the instance",
+		Example:               editVariableExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(cmd))
+		},
+		SuggestFor: []string{"variables"},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *EditOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	addAttributeFlags(cmd, &o.changes, &o.ValueFile)
+	cmd.Flags().StringVar(&o.Scope, "scope", o.Scope,
+		"The environment scope of the variable to edit, all the environments by default")
+}
+
+// Complete completes all the required options.
+func (o *EditOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	if err != nil {
+		return err
+	}
+	o.key = args[0]
+	return readValueFile(&o.changes, o.ValueFile)
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *EditOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd, o.Scope); err != nil {
+		return err
+	}
+	if err := validateAttributes(cmd); err != nil {
+		return err
+	}
+	for _, name := range editVariableFlags {
+		if cmd.Flags().Changed(name) {
+			return nil
+		}
+	}
+	return cmdutil.UsageErrorf(cmd, "nothing to edit, use at least one of --%s", strings.Join(editVariableFlags, ", --"))
+}
+
+// Run executes a edit subcommand using the specified options.
+func (o *EditOptions) Run(cmd *cobra.Command) error {
+	scope := o.Scope
+	if scope == "" {
+		scope = o.defaultScope()
+	}
+	old, err := o.find(o.key, scope)
+	if err != nil {
+		return err
+	}
+	v := *old
+	flags := cmd.Flags()
+	if flags.Changed("value") || flags.Changed("value-file") {
+		v.Value = o.changes.Value
+	}
+	if flags.Changed("type") {
+		v.Type = o.changes.Type
+	}
+	if flags.Changed("protected") {
+		v.Protected = o.changes.Protected
+	}
+	if flags.Changed("masked") {
+		v.Masked = o.changes.Masked
+	}
+	if flags.Changed("raw") {
+		v.Raw = o.changes.Raw
+	}
+	if flags.Changed("description") {
+		v.Description = o.changes.Description
+	}
+	if err = o.update(old, &v); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.ioStreams.Out, "variable %s edited in %s\n", v.id(), o.target.String())
+	return nil
+}
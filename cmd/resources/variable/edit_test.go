@@ -0,0 +1,99 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestEditVariable(t *testing.T) {
+	project := "Group2/SubGroup3/Project13"
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	client, err := factory.GitlabClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	createTestVariable(t, client, project, "GLCTL_EDIT")
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		wantOut   string
+		wantError error
+	}{{
+		name:  "nothing to edit",
+		args:  []string{"GLCTL_EDIT"},
+		flags: []string{"-p=" + project},
+		wantError: errors.New("nothing to edit, use at least one of --value, --value-file, --type, --protected, " +
+			"--masked, --raw, --description\nSee 'variable -h' for help and examples"),
+	}, {
+		name:      "unknown type",
+		args:      []string{"GLCTL_EDIT"},
+		flags:     []string{"-p=" + project, "--type=secret"},
+		wantError: errors.New("'secret' is not a recognized value of 'type' flag; choose from [env_var, file]"),
+	}, {
+		name:      "variable not found",
+		args:      []string{"GLCTL_EDIT"},
+		flags:     []string{"-p=" + project, "--scope=staging", "--value=info"},
+		wantError: errors.New("variable GLCTL_EDIT (staging) not found in project " + project),
+	}, {
+		name:    "edit a variable",
+		args:    []string{"GLCTL_EDIT"},
+		flags:   []string{"-p=" + project, "--value=info", "--raw", "--description=edited by a test"},
+		wantOut: "variable GLCTL_EDIT edited in project " + project + "\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsForPipe()
+			cmd := &cobra.Command{Use: "variable"}
+			cmdOptions := NewEditOptions(streams)
+			cmdOptions.AddFlags(cmd)
+			if err := cmd.ParseFlags(tc.flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			out := cmdtesting.RunForStdout(streams, func() {
+				err = cmdOptions.Run(cmd)
+			})
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			assert.Equal(t, tc.wantOut, out)
+		})
+	}
+	v, err := (&target{client: client, project: project}).find("GLCTL_EDIT", allEnvironments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "info", v.Value)
+	assert.True(t, v.Raw)
+	assert.Equal(t, "edited by a test", v.Description)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+var (
+	exportFormats = []string{"dotenv", "yaml"}
+
+	// plainDotenvValue matches the values written without quotes.
+	plainDotenvValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+	dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+)
+
+type ExportOptions struct {
+	target
+	Out       string
+	Scope     string
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	exportVariablesExample = templates.Examples(`
+# export the variables of the production environment of a project to a .env file
+glctl export variables -p group/myapp --scope=production > .env
+
+# export every variable of a group, to apply them to another group later
+glctl export variables -G group -o yaml > vars.yaml`)
+)
+
+func NewExportOptions(ioStreams genericiooptions.IOStreams) *ExportOptions {
+	return &ExportOptions{
+		ioStreams: ioStreams,
+		Out:       exportFormats[0],
+	}
+}
+
+func NewExportVariablesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewExportOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variables",
+		Aliases:               []string{"variable", "var", "vars"},
+		Short:                 "Export the CI/CD variables of a project, a group or the instance with their values",
+		Example:               exportVariablesExample,
+		Args:                  require.NoArgs,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.target.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringVarP(&o.Out, "out", "o", o.Out,
+		fmt.Sprintf("The format of the export (%s)", strings.Join(exportFormats, ", ")))
+	flags.StringVar(&o.Scope, "scope", o.Scope, "Only export the variables of this environment scope")
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("out",
+		cobra.FixedCompletions(exportFormats, cobra.ShellCompDirectiveNoFileComp)))
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *ExportOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ExportOptions) Validate(cmd *cobra.Command, args []string) error {
+	if err := o.target.validate(cmd, o.Scope); err != nil {
+		return err
+	}
+	return validate.ValidateFlagStringValue(exportFormats, cmd, "out")
+}
+
+// Run executes an export subcommand using the specified options.
+func (o *ExportOptions) Run(args []string) error {
+	variables, err := o.list()
+	if err != nil {
+		return err
+	}
+	var exported []*Variable
+	for _, v := range filterScope(variables, o.Scope) {
+		if v.Hidden {
+			_, _ = fmt.Fprintf(o.ioStreams.ErrOut, "warning: variable %s is hidden, its value can not be exported\n", v.id())
+			continue
+		}
+		exported = append(exported, v)
+	}
+	if o.Out == "yaml" {
+		b, err := yaml.Marshal(exported)
+		if err != nil {
+			return err
+		}
+		_, err = o.ioStreams.Out.Write(b)
+		return err
+	}
+	return writeDotenv(o.ioStreams.Out, exported)
+}
+
+// writeDotenv writes the variables as KEY=value lines, a key defined for
+// several environments can not be written.
+func writeDotenv(w io.Writer, variables []*Variable) error {
+	seen := map[string]bool{}
+	for _, v := range variables {
+		if seen[v.Key] {
+			return fmt.Errorf("variable %s is defined for several environments, choose one with --scope", v.Key)
+		}
+		seen[v.Key] = true
+	}
+	for _, v := range variables {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", v.Key, dotenvValue(v.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dotenvValue quotes value unless it only has characters every dotenv
+// parser reads as is.
+func dotenvValue(value string) string {
+	if plainDotenvValue.MatchString(value) {
+		return value
+	}
+	return `"` + dotenvEscaper.Replace(value) + `"`
+}
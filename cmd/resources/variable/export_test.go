@@ -0,0 +1,58 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestWriteDotenv(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeDotenv(&buf, []*Variable{
+		{Key: "API_URL", Value: "https://api.example.com:8443/v1?a=b"},
+		{Key: "EMPTY"},
+		{Key: "GREETING", Value: `say "hi" to $USER`},
+		{Key: "KUBECONFIG", Value: "apiVersion: v1\nclusters: []\n"},
+		{Key: "WINDOWS_PATH", Value: `C:\tools`},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `API_URL="https://api.example.com:8443/v1?a=b"
+EMPTY=
+GREETING="say \"hi\" to \$USER"
+KUBECONFIG="apiVersion: v1\nclusters: []\n"
+WINDOWS_PATH="C:\\tools"
+`, buf.String())
+
+	buf.Reset()
+	err = writeDotenv(&buf, []*Variable{
+		{Key: "DB_PASSWORD", EnvironmentScope: "production"},
+		{Key: "DB_PASSWORD", EnvironmentScope: "staging"},
+	})
+	cmdtesting.ErrorAssertionWithEqual(t,
+		errors.New("variable DB_PASSWORD is defined for several environments, choose one with --scope"), err)
+	assert.Empty(t, buf.String())
+}
+
+func TestDotenvValue(t *testing.T) {
+	assert.Equal(t, "debug", dotenvValue("debug"))
+	assert.Equal(t, "postgres://db:5432/app", dotenvValue("postgres://db:5432/app"))
+	assert.Equal(t, `"two words"`, dotenvValue("two words"))
+}
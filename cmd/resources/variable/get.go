@@ -0,0 +1,138 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+)
+
+// previewLength is the number of characters of a value shown in a table.
+const previewLength = 40
+
+type ListOptions struct {
+	target
+	Out       string
+	Scope     string
+	Reveal    bool
+	ioStreams genericiooptions.IOStreams
+}
+
+var (
+	getVariablesExample = templates.Examples(`
+# list the variables of a project, masked values are hidden
+glctl get variables -p group1/devops
+
+# list the variables of the production environment with their values
+glctl get variables -p group1/devops --scope=production --reveal
+
+# list the variables of a group as yaml
+glctl get variables -G group1 -o yaml
+
+# list the variables of the instance
+glctl get variables --instance`)
+)
+
+func NewListOptions(ioStreams genericiooptions.IOStreams) *ListOptions {
+	return &ListOptions{
+		ioStreams: ioStreams,
+		Out:       "simple",
+	}
+}
+
+func NewGetVariablesCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewListOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "variables",
+		Aliases:               []string{"variable", "var", "vars"},
+		Short:                 "List the CI/CD variables of a project, a group or the instance",
+		Example:               getVariablesExample,
+		DisableFlagsInUseLine: true,
+		TraverseChildren:      true,
+		Args:                  require.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	return cmd
+}
+
+// AddFlags registers flags for a cli
+func (o *ListOptions) AddFlags(cmd *cobra.Command) {
+	o.target.addFlags(cmd)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	f := cmd.Flags()
+	f.StringVar(&o.Scope, "scope", o.Scope, "Only list the variables of this environment scope")
+	f.BoolVar(&o.Reveal, "reveal", o.Reveal, "Show the values of masked variables")
+}
+
+// Complete completes all the required options.
+func (o *ListOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.client, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *ListOptions) Validate(cmd *cobra.Command, args []string) error {
+	return o.target.validate(cmd, o.Scope)
+}
+
+// Run executes a list subcommand using the specified options.
+func (o *ListOptions) Run(args []string) error {
+	variables, err := o.list()
+	if err != nil {
+		return err
+	}
+	variables = filterScope(variables, o.Scope)
+	if !o.Reveal {
+		variables = hide(variables)
+	}
+	printer := cmdutil.NewListPrinter(o.Out, o.ioStreams.Out, []string{"KEY", "VALUE", "TYPE", "SCOPE", "FLAGS"},
+		func(v *Variable) []string {
+			return []string{v.Key, preview(v), v.Type, v.EnvironmentScope, flags(v)}
+		})
+	if err = printer.PrintChunk(variables); err != nil {
+		return err
+	}
+	return printer.Flush()
+}
+
+// preview returns the first line of the value of v, cut to fit in a table.
+func preview(v *Variable) string {
+	if v.Hidden && v.Value == "" {
+		return maskedValue
+	}
+	value, _, multiline := strings.Cut(v.Value, "\n")
+	if r := []rune(value); len(r) > previewLength {
+		value, multiline = string(r[:previewLength]), true
+	}
+	if multiline {
+		value += "..."
+	}
+	return value
+}
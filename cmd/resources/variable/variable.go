@@ -0,0 +1,377 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+const (
+	// allEnvironments is the environment scope of a project or group
+	// variable available to every environment.
+	allEnvironments = "*"
+
+	maskedValue = "[masked]"
+)
+
+var variableTypes = []string{string(gitlab.EnvVariableType), string(gitlab.FileVariableType)}
+
+// Variable is a CI/CD variable of a project, a group or the instance, in the
+// form variables are printed, exported and applied.
+type Variable struct {
+	Key              string `json:"key" yaml:"key"`
+	Value            string `json:"value" yaml:"value"`
+	Type             string `json:"type" yaml:"type,omitempty"`
+	EnvironmentScope string `json:"environment_scope,omitempty" yaml:"environment_scope,omitempty"`
+	Protected        bool   `json:"protected" yaml:"protected,omitempty"`
+	Masked           bool   `json:"masked" yaml:"masked,omitempty"`
+	Raw              bool   `json:"raw" yaml:"raw,omitempty"`
+	Description      string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Hidden is set for masked variables whose value is never sent back by
+	// the server.
+	Hidden bool `json:"hidden,omitempty" yaml:"-"`
+}
+
+// id identifies a variable, a key may be defined once per environment scope.
+func (v *Variable) id() string {
+	if v.EnvironmentScope == "" || v.EnvironmentScope == allEnvironments {
+		return v.Key
+	}
+	return v.Key + " (" + v.EnvironmentScope + ")"
+}
+
+// target is the project, group or instance variables are managed in.
+type target struct {
+	client   *gitlab.Client
+	project  string
+	group    string
+	instance bool
+}
+
+// addFlags registers the flags choosing the target.
+func (t *target) addFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &t.project)
+	cmdutil.AddFromGroupVarPFlag(cmd, &t.group)
+	cmd.Flags().BoolVar(&t.instance, "instance", t.instance, "Use the variables of the instance, which requires an administrator")
+}
+
+// validate makes sure exactly one target is given and that an environment
+// scope is only used for a project or a group.
+func (t *target) validate(cmd *cobra.Command, scope string) error {
+	n := 0
+	for _, set := range []bool{t.project != "", t.group != "", t.instance} {
+		if set {
+			n++
+		}
+	}
+	switch {
+	case n == 0:
+		return cmdutil.UsageErrorf(cmd, "one of --project, --group or --instance is required")
+	case n > 1:
+		return cmdutil.UsageErrorf(cmd, "only one of --project, --group and --instance can be used")
+	case t.instance && scope != "":
+		return cmdutil.UsageErrorf(cmd, "instance variables have no environment scope")
+	}
+	return nil
+}
+
+func (t *target) String() string {
+	switch {
+	case t.project != "":
+		return "project " + t.project
+	case t.group != "":
+		return "group " + t.group
+	default:
+		return "the instance"
+	}
+}
+
+// defaultScope returns the environment scope of a variable defined without
+// one, the instance has no environment scopes.
+func (t *target) defaultScope() string {
+	if t.instance {
+		return ""
+	}
+	return allEnvironments
+}
+
+// list returns the variables of the target ordered by key and environment
+// scope.
+func (t *target) list() ([]*Variable, error) {
+	var variables []*Variable
+	chunk := gitlab.ListOptions{PerPage: cmdutil.DefaultChunkSize}
+	var err error
+	switch {
+	case t.project != "":
+		opt := &gitlab.ListProjectVariablesOptions{ListOptions: chunk}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+				return t.client.ProjectVariables.ListVariables(t.project, opt, options...)
+			}, func(page []*gitlab.ProjectVariable) error {
+				for _, v := range page {
+					variables = append(variables, fromProjectVariable(v))
+				}
+				return nil
+			})
+	case t.group != "":
+		opt := &gitlab.ListGroupVariablesOptions{ListOptions: chunk}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+				return t.client.GroupVariables.ListVariables(t.group, opt, options...)
+			}, func(page []*gitlab.GroupVariable) error {
+				for _, v := range page {
+					variables = append(variables, fromGroupVariable(v))
+				}
+				return nil
+			})
+	default:
+		opt := &gitlab.ListInstanceVariablesOptions{ListOptions: chunk}
+		err = cmdutil.ListPages(true, 0,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.InstanceVariable, *gitlab.Response, error) {
+				return t.client.InstanceVariables.ListVariables(opt, options...)
+			}, func(page []*gitlab.InstanceVariable) error {
+				for _, v := range page {
+					variables = append(variables, fromInstanceVariable(v))
+				}
+				return nil
+			})
+	}
+	sortVariables(variables)
+	return variables, err
+}
+
+// create creates the variable v.
+func (t *target) create(v *Variable) error {
+	var err error
+	switch {
+	case t.project != "":
+		_, _, err = t.client.ProjectVariables.CreateVariable(t.project, &gitlab.CreateProjectVariableOptions{
+			Key:              pointer.ToString(v.Key),
+			Value:            pointer.ToString(v.Value),
+			Description:      pointer.ToString(v.Description),
+			EnvironmentScope: pointer.ToString(v.EnvironmentScope),
+			Masked:           pointer.ToBool(v.Masked),
+			Protected:        pointer.ToBool(v.Protected),
+			Raw:              pointer.ToBool(v.Raw),
+			VariableType:     variableType(v.Type),
+		})
+	case t.group != "":
+		_, _, err = t.client.GroupVariables.CreateVariable(t.group, &gitlab.CreateGroupVariableOptions{
+			Key:              pointer.ToString(v.Key),
+			Value:            pointer.ToString(v.Value),
+			Description:      pointer.ToString(v.Description),
+			EnvironmentScope: pointer.ToString(v.EnvironmentScope),
+			Masked:           pointer.ToBool(v.Masked),
+			Protected:        pointer.ToBool(v.Protected),
+			Raw:              pointer.ToBool(v.Raw),
+			VariableType:     variableType(v.Type),
+		})
+	default:
+		_, _, err = t.client.InstanceVariables.CreateVariable(&gitlab.CreateInstanceVariableOptions{
+			Key:          pointer.ToString(v.Key),
+			Value:        pointer.ToString(v.Value),
+			Description:  pointer.ToString(v.Description),
+			Masked:       pointer.ToBool(v.Masked),
+			Protected:    pointer.ToBool(v.Protected),
+			Raw:          pointer.ToBool(v.Raw),
+			VariableType: variableType(v.Type),
+		})
+	}
+	return err
+}
+
+// update sets the attributes of the variable old to the ones of v, the value
+// is only sent when it changed since it is unknown for a hidden variable.
+func (t *target) update(old, v *Variable) error {
+	var err error
+	var value *string
+	if v.Value != old.Value {
+		value = pointer.ToString(v.Value)
+	}
+	filter := &gitlab.VariableFilter{EnvironmentScope: v.EnvironmentScope}
+	switch {
+	case t.project != "":
+		_, _, err = t.client.ProjectVariables.UpdateVariable(t.project, v.Key, &gitlab.UpdateProjectVariableOptions{
+			Value:        value,
+			Description:  pointer.ToString(v.Description),
+			Filter:       filter,
+			Masked:       pointer.ToBool(v.Masked),
+			Protected:    pointer.ToBool(v.Protected),
+			Raw:          pointer.ToBool(v.Raw),
+			VariableType: variableType(v.Type),
+		})
+	case t.group != "":
+		_, _, err = t.client.GroupVariables.UpdateVariable(t.group, v.Key, &gitlab.UpdateGroupVariableOptions{
+			Value:        value,
+			Description:  pointer.ToString(v.Description),
+			Filter:       filter,
+			Masked:       pointer.ToBool(v.Masked),
+			Protected:    pointer.ToBool(v.Protected),
+			Raw:          pointer.ToBool(v.Raw),
+			VariableType: variableType(v.Type),
+		})
+	default:
+		_, _, err = t.client.InstanceVariables.UpdateVariable(v.Key, &gitlab.UpdateInstanceVariableOptions{
+			Value:        value,
+			Description:  pointer.ToString(v.Description),
+			Masked:       pointer.ToBool(v.Masked),
+			Protected:    pointer.ToBool(v.Protected),
+			Raw:          pointer.ToBool(v.Raw),
+			VariableType: variableType(v.Type),
+		})
+	}
+	return err
+}
+
+// remove deletes the variable with the key in the environment scope.
+func (t *target) remove(key, scope string) error {
+	var err error
+	filter := &gitlab.VariableFilter{EnvironmentScope: scope}
+	switch {
+	case t.project != "":
+		_, err = t.client.ProjectVariables.RemoveVariable(t.project, key,
+			&gitlab.RemoveProjectVariableOptions{Filter: filter})
+	case t.group != "":
+		_, err = t.client.GroupVariables.RemoveVariable(t.group, key,
+			&gitlab.RemoveGroupVariableOptions{Filter: filter})
+	default:
+		_, err = t.client.InstanceVariables.RemoveVariable(key)
+	}
+	return err
+}
+
+// find returns the variable with the key in the environment scope.
+func (t *target) find(key, scope string) (*Variable, error) {
+	variables, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range variables {
+		if v.Key == key && v.EnvironmentScope == scope {
+			return v, nil
+		}
+	}
+	v := &Variable{Key: key, EnvironmentScope: scope}
+	return nil, fmt.Errorf("variable %s not found in %s", v.id(), t)
+}
+
+func fromProjectVariable(v *gitlab.ProjectVariable) *Variable {
+	return &Variable{
+		Key:              v.Key,
+		Value:            v.Value,
+		Type:             string(v.VariableType),
+		EnvironmentScope: v.EnvironmentScope,
+		Protected:        v.Protected,
+		Masked:           v.Masked,
+		Raw:              v.Raw,
+		Description:      v.Description,
+		Hidden:           v.Hidden,
+	}
+}
+
+func fromGroupVariable(v *gitlab.GroupVariable) *Variable {
+	return &Variable{
+		Key:              v.Key,
+		Value:            v.Value,
+		Type:             string(v.VariableType),
+		EnvironmentScope: v.EnvironmentScope,
+		Protected:        v.Protected,
+		Masked:           v.Masked,
+		Raw:              v.Raw,
+		Description:      v.Description,
+		Hidden:           v.Hidden,
+	}
+}
+
+func fromInstanceVariable(v *gitlab.InstanceVariable) *Variable {
+	return &Variable{
+		Key:         v.Key,
+		Value:       v.Value,
+		Type:        string(v.VariableType),
+		Protected:   v.Protected,
+		Masked:      v.Masked,
+		Raw:         v.Raw,
+		Description: v.Description,
+	}
+}
+
+// variableType returns the type to send for t, env_var when it is empty.
+func variableType(t string) *gitlab.VariableTypeValue {
+	if t == "" {
+		t = string(gitlab.EnvVariableType)
+	}
+	return gitlab.Ptr(gitlab.VariableTypeValue(t))
+}
+
+func sortVariables(variables []*Variable) {
+	sort.SliceStable(variables, func(i, j int) bool {
+		if variables[i].Key != variables[j].Key {
+			return variables[i].Key < variables[j].Key
+		}
+		return variables[i].EnvironmentScope < variables[j].EnvironmentScope
+	})
+}
+
+// hide returns copies of the variables with their masked values replaced.
+func hide(variables []*Variable) []*Variable {
+	hidden := make([]*Variable, 0, len(variables))
+	for _, v := range variables {
+		c := *v
+		if c.Masked {
+			c.Value = maskedValue
+		}
+		hidden = append(hidden, &c)
+	}
+	return hidden
+}
+
+// filterScope returns the variables of the environment scope, or all of
+// them when scope is empty.
+func filterScope(variables []*Variable, scope string) []*Variable {
+	if scope == "" {
+		return variables
+	}
+	var filtered []*Variable
+	for _, v := range variables {
+		if v.EnvironmentScope == scope {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// flags returns the attributes of a variable shown in a table.
+func flags(v *Variable) string {
+	var set []string
+	if v.Protected {
+		set = append(set, "protected")
+	}
+	if v.Masked {
+		set = append(set, "masked")
+	}
+	if v.Raw {
+		set = append(set, "raw")
+	}
+	return strings.Join(set, ",")
+}
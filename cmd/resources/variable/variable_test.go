@@ -0,0 +1,75 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+)
+
+func TestValidateTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    target
+		scope     string
+		wantError error
+	}{{
+		name:   "project",
+		target: target{project: "group/myapp"},
+		scope:  "production",
+	}, {
+		name:      "none",
+		wantError: errors.New("one of --project, --group or --instance is required\nSee 'variables -h' for help and examples"),
+	}, {
+		name:      "several",
+		target:    target{group: "group", instance: true},
+		wantError: errors.New("only one of --project, --group and --instance can be used\nSee 'variables -h' for help and examples"),
+	}, {
+		name:      "scope of the instance",
+		target:    target{instance: true},
+		scope:     "production",
+		wantError: errors.New("instance variables have no environment scope\nSee 'variables -h' for help and examples"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.target.validate(&cobra.Command{Use: "variables"}, tc.scope)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if tc.wantError == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	assert.Equal(t, "debug", preview(&Variable{Value: "debug"}))
+	assert.Equal(t, "apiVersion: v1...", preview(&Variable{Value: "apiVersion: v1\nclusters: []\n"}))
+	assert.Equal(t, "0123456789012345678901234567890123456789...",
+		preview(&Variable{Value: "0123456789012345678901234567890123456789-cut"}))
+	assert.Equal(t, maskedValue, preview(&Variable{Masked: true, Hidden: true}))
+}
+
+func TestHide(t *testing.T) {
+	variables := []*Variable{{Key: "A", Value: "1"}, {Key: "B", Value: "2", Masked: true}}
+	hidden := hide(variables)
+	assert.Equal(t, "1", hidden[0].Value)
+	assert.Equal(t, maskedValue, hidden[1].Value)
+	assert.Equal(t, "2", variables[1].Value)
+}
@@ -0,0 +1,358 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/require"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+	"github.com/huhouhua/glctl/cmd/util/completion"
+	"github.com/huhouhua/glctl/cmd/validate"
+)
+
+const (
+	scopeBlobs         = "blobs"
+	scopeCommits       = "commits"
+	scopeProjects      = "projects"
+	scopeIssues        = "issues"
+	scopeMergeRequests = "merge_requests"
+)
+
+var scopes = []string{scopeBlobs, scopeCommits, scopeProjects, scopeIssues, scopeMergeRequests}
+
+// SearchOptions is a struct to support search command.
+type SearchOptions struct {
+	gitlabClient *gitlab.Client
+	search       *gitlab.SearchOptions
+	query        string
+	Scope        string
+	Group        string
+	Project      string
+	Ref          string
+	Out          string
+	All          bool
+	Limit        int64
+	ChunkSize    int64
+	ioStreams    genericiooptions.IOStreams
+}
+
+func NewSearchOptions(ioStreams genericiooptions.IOStreams) *SearchOptions {
+	return &SearchOptions{
+		ioStreams: ioStreams,
+		search: &gitlab.SearchOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    1,
+				PerPage: 20,
+			},
+		},
+		Scope:     scopeBlobs,
+		ChunkSize: cmdutil.DefaultChunkSize,
+		Out:       "simple",
+	}
+}
+
+var (
+	searchLong = templates.LongDesc(`
+		Search code, commits, projects, issues and merge requests with the
+		GitLab search API, across the instance, a group or a project.
+
+		Blob results print the matched lines prefixed with the project, ref,
+		file and line number, like grep.`)
+
+	searchExample = templates.Examples(`
+		# find every repository that still references a deprecated image
+		glctl search "registry.example.com/base:1.0" --all
+
+		# search the code of a group
+		glctl search DEPLOY_TOKEN --group=mygroup
+
+		# search the commits of a project on a branch
+		glctl search "fix login" --scope=commits --project=mygroup/myproject --ref=main
+
+		# list the open merge requests mentioning a variable as json
+		glctl search OLD_VARIABLE --scope=merge_requests -o json`)
+)
+
+func NewSearchCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewSearchOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "search <query>",
+		Short:                 "Search code, commits, projects, issues and merge requests",
+		Long:                  searchLong,
+		Example:               searchExample,
+		Args:                  require.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+	o.AddFlags(cmd)
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("project", completion.ProjectCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("group", completion.GroupCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("ref", completion.BranchCompletionFunc(f)))
+	cmdutil.CheckErr(cmd.RegisterFlagCompletionFunc("scope",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return scopes, cobra.ShellCompDirectiveNoFileComp
+		}))
+	return cmd
+}
+
+func (o *SearchOptions) AddFlags(cmd *cobra.Command) {
+	cmdutil.AddProjectVarPFlag(cmd, &o.Project)
+	cmdutil.AddOutFlag(cmd, &o.Out)
+	cmdutil.AddLimitVarFlag(cmd, &o.Limit)
+	cmdutil.AddChunkSizeVarFlag(cmd, &o.ChunkSize)
+	f := cmd.Flags()
+	f.StringVar(&o.Scope, "scope", o.Scope, "What to search, one of "+strings.Join(scopes, ", "))
+	f.StringVarP(&o.Group, "group", "G", o.Group, "Only search in the projects of this group")
+	f.StringVar(&o.Ref, "ref", o.Ref, "The branch or tag to search blobs and commits of --project in")
+	f.BoolVarP(&o.All, "all", "A", o.All, "If present, list every result instead of the first page")
+}
+
+// Complete completes all the required options.
+func (o *SearchOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	if len(args) > 0 {
+		o.query = args[0]
+	}
+	if o.Ref != "" {
+		o.search.Ref = pointer.ToString(o.Ref)
+	}
+	if o.All {
+		o.search.PerPage = o.ChunkSize
+	}
+	o.gitlabClient, err = f.GitlabClient()
+	return err
+}
+
+// Validate makes sure there is no discrepency in command options.
+func (o *SearchOptions) Validate(cmd *cobra.Command, args []string) error {
+	if strings.TrimSpace(o.query) == "" {
+		return cmdutil.UsageErrorf(cmd, "please enter the text to search")
+	}
+	if err := validate.ValidateFlagStringValue(scopes, cmd, "scope"); err != nil {
+		return err
+	}
+	if o.Group != "" && o.Project != "" {
+		return cmdutil.UsageErrorf(cmd, "--group can not be combined with --project")
+	}
+	if o.Scope == scopeProjects && o.Project != "" {
+		return cmdutil.UsageErrorf(cmd, "--scope=projects can not be combined with --project")
+	}
+	if o.Ref != "" && (o.Project == "" || (o.Scope != scopeBlobs && o.Scope != scopeCommits)) {
+		return cmdutil.UsageErrorf(cmd, "--ref requires --project and the blobs or commits scope")
+	}
+	return nil
+}
+
+// Run executes a search command using the specified options.
+func (o *SearchOptions) Run(args []string) error {
+	search := o.gitlabClient.Search
+	switch o.Scope {
+	case scopeCommits:
+		return searchPages(o, cmdutil.NewCommitsPrinter(o.Out, o.ioStreams.Out),
+			search.Commits, search.CommitsByGroup, search.CommitsByProject)
+	case scopeProjects:
+		return searchPages(o, cmdutil.NewProjectsPrinter(o.Out, o.ioStreams.Out),
+			search.Projects, search.ProjectsByGroup, nil)
+	case scopeIssues:
+		return searchPages(o, cmdutil.NewIssuesPrinter(o.Out, o.ioStreams.Out),
+			search.Issues, search.IssuesByGroup, search.IssuesByProject)
+	case scopeMergeRequests:
+		return searchPages(o, newMergeRequestsPrinter(o.Out, o.ioStreams.Out),
+			search.MergeRequests, search.MergeRequestsByGroup, search.MergeRequestsByProject)
+	default:
+		return searchPages(o, newBlobsPrinter(o),
+			search.Blobs, search.BlobsByGroup, search.BlobsByProject)
+	}
+}
+
+type (
+	searchFunc[T any] func(query string, opt *gitlab.SearchOptions,
+		options ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error)
+	scopedSearchFunc[T any] func(id any, query string, opt *gitlab.SearchOptions,
+		options ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error)
+)
+
+// printer is the part of cmdutil.ListPrinter searchPages relies on.
+type printer[T any] interface {
+	PrintChunk(items []T) error
+	Flush() error
+}
+
+// searchPages runs the search of the instance, --group or --project and
+// prints the results page by page.
+func searchPages[T any](o *SearchOptions, p printer[T], search searchFunc[T], byGroup, byProject scopedSearchFunc[T]) error {
+	err := cmdutil.ListPages(o.All, o.Limit,
+		func(options ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error) {
+			switch {
+			case o.Project != "":
+				return byProject(o.Project, o.query, o.search, options...)
+			case o.Group != "":
+				return byGroup(o.Group, o.query, o.search, options...)
+			}
+			return search(o.query, o.search, options...)
+		}, p.PrintChunk)
+	if err != nil {
+		return err
+	}
+	return p.Flush()
+}
+
+// mergeRequestsPrinter prints the merge requests found with the printer of
+// the listed ones.
+type mergeRequestsPrinter struct {
+	*cmdutil.ListPrinter[*gitlab.BasicMergeRequest]
+}
+
+func newMergeRequestsPrinter(format string, w io.Writer) *mergeRequestsPrinter {
+	return &mergeRequestsPrinter{cmdutil.NewMergeRequestsPrinter(format, w)}
+}
+
+func (p *mergeRequestsPrinter) PrintChunk(items []*gitlab.MergeRequest) error {
+	basic := make([]*gitlab.BasicMergeRequest, 0, len(items))
+	for _, mr := range items {
+		basic = append(basic, &mr.BasicMergeRequest)
+	}
+	return p.ListPrinter.PrintChunk(basic)
+}
+
+// blobMatch is a blob found by the search with its project and lines.
+type blobMatch struct {
+	ProjectID int64       `json:"project_id" yaml:"project_id"`
+	Project   string      `json:"project"    yaml:"project"`
+	Ref       string      `json:"ref"        yaml:"ref"`
+	Path      string      `json:"path"       yaml:"path"`
+	Lines     []*blobLine `json:"lines"      yaml:"lines"`
+}
+
+// blobLine is a line of a blob, the search returns the lines around the
+// matched ones too.
+type blobLine struct {
+	Line    int64  `json:"line"    yaml:"line"`
+	Content string `json:"content" yaml:"content"`
+	Match   bool   `json:"match"   yaml:"match"`
+}
+
+// blobsPrinter prints the blobs found like grep, or as a list of blobMatch
+// in the json and yaml formats.
+type blobsPrinter struct {
+	o        *SearchOptions
+	list     *cmdutil.ListPrinter[*blobMatch]
+	projects map[int64]string
+	match    *regexp.Regexp
+	count    int
+}
+
+func newBlobsPrinter(o *SearchOptions) *blobsPrinter {
+	return &blobsPrinter{
+		o:        o,
+		list:     cmdutil.NewListPrinter[*blobMatch](o.Out, o.ioStreams.Out, nil, nil),
+		projects: map[int64]string{},
+		match:    regexp.MustCompile("(?i)" + regexp.QuoteMeta(o.query)),
+	}
+}
+
+func (p *blobsPrinter) PrintChunk(items []*gitlab.Blob) error {
+	matches := make([]*blobMatch, 0, len(items))
+	for _, blob := range items {
+		matches = append(matches, p.blobMatch(blob))
+	}
+	p.count += len(matches)
+	if p.o.Out == cmdutil.JSON || p.o.Out == cmdutil.YAML {
+		return p.list.PrintChunk(matches)
+	}
+	for _, m := range matches {
+		for _, line := range m.Lines {
+			sep, content := "-", line.Content
+			if line.Match {
+				sep = ":"
+				content = p.match.ReplaceAllStringFunc(content, func(s string) string {
+					return color.New(color.FgRed, color.Bold).Sprint(s)
+				})
+			}
+			_, err := fmt.Fprintf(p.o.ioStreams.Out, "%s%s%s%s%s\n",
+				color.MagentaString("%s:%s:%s", m.Project, m.Ref, m.Path), sep,
+				color.GreenString("%d", line.Line), sep, content)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *blobsPrinter) Flush() error {
+	if p.o.Out == cmdutil.JSON || p.o.Out == cmdutil.YAML {
+		return p.list.Flush()
+	}
+	if p.count == 0 {
+		_, err := fmt.Fprintln(p.o.ioStreams.ErrOut, "no match found")
+		return err
+	}
+	return nil
+}
+
+// blobMatch splits the data of blob into numbered lines, a line is matched
+// when it contains the query. An advanced search query may match no line
+// literally, all of them are matched then.
+func (p *blobsPrinter) blobMatch(blob *gitlab.Blob) *blobMatch {
+	m := &blobMatch{
+		ProjectID: blob.ProjectID,
+		Project:   p.project(blob.ProjectID),
+		Ref:       blob.Ref,
+		Path:      blob.Path,
+	}
+	matched := false
+	for i, content := range strings.Split(strings.TrimSuffix(blob.Data, "\n"), "\n") {
+		line := &blobLine{Line: blob.Startline + int64(i), Content: content, Match: p.match.MatchString(content)}
+		matched = matched || line.Match
+		m.Lines = append(m.Lines, line)
+	}
+	if !matched {
+		for _, line := range m.Lines {
+			line.Match = true
+		}
+	}
+	return m
+}
+
+// project returns the path of the project with id, looked up once.
+func (p *blobsPrinter) project(id int64) string {
+	if path, ok := p.projects[id]; ok {
+		return path
+	}
+	path := fmt.Sprint(id)
+	project, _, err := p.o.gitlabClient.Projects.GetProject(id, &gitlab.GetProjectOptions{})
+	if err == nil {
+		path = project.PathWithNamespace
+	}
+	p.projects[id] = path
+	return path
+}
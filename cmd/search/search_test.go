@@ -0,0 +1,119 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+
+	cmdtesting "github.com/huhouhua/glctl/cmd/testing"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+func TestRunSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		optionsFunc func(opt *SearchOptions)
+		wantError   error
+	}{{
+		name:      "search blobs",
+		args:      []string{"image"},
+		wantError: nil,
+	}, {
+		name: "search blobs of a project as json",
+		args: []string{"image"},
+		optionsFunc: func(opt *SearchOptions) {
+			opt.Project = "Group2/SubGroup3/Project13"
+			opt.Ref = "main"
+			opt.Out = "json"
+		},
+		wantError: nil,
+	}, {
+		name: "search projects of a group",
+		args: []string{"Project"},
+		optionsFunc: func(opt *SearchOptions) {
+			opt.Scope = scopeProjects
+			opt.Group = "Group2"
+		},
+		wantError: nil,
+	}, {
+		name: "search every merge request",
+		args: []string{"update"},
+		optionsFunc: func(opt *SearchOptions) {
+			opt.Scope = scopeMergeRequests
+			opt.All = true
+			opt.Limit = 10
+		},
+		wantError: nil,
+	}}
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	factory := cmdutil.NewFactory(cmdtesting.NewFakeRESTClientGetter())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewSearchCmd(factory, streams)
+			var cmdOptions = NewSearchOptions(streams)
+			if tc.optionsFunc != nil {
+				tc.optionsFunc(cmdOptions)
+			}
+			err := cmdOptions.Complete(factory, cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Validate(cmd, tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+			if err != nil {
+				return
+			}
+			err = cmdOptions.Run(tc.args)
+			cmdtesting.ErrorAssertionWithEqual(t, tc.wantError, err)
+		})
+	}
+}
+
+func TestBlobMatch(t *testing.T) {
+	o := NewSearchOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.query = "Image"
+	p := newBlobsPrinter(o)
+	p.projects[1] = "g/p"
+	got := p.blobMatch(&gitlab.Blob{
+		ProjectID: 1,
+		Ref:       "main",
+		Path:      "deploy/app.yaml",
+		Data:      "name: app\nimage: base:1.0\n",
+		Startline: 7,
+	})
+	assert.Equal(t, &blobMatch{
+		ProjectID: 1,
+		Project:   "g/p",
+		Ref:       "main",
+		Path:      "deploy/app.yaml",
+		Lines: []*blobLine{
+			{Line: 7, Content: "name: app"},
+			{Line: 8, Content: "image: base:1.0", Match: true},
+		},
+	}, got)
+
+	o.query = "extension:yaml base"
+	p = newBlobsPrinter(o)
+	p.projects[1] = "g/p"
+	got = p.blobMatch(&gitlab.Blob{ProjectID: 1, Data: "image: base:1.0", Startline: 1})
+	assert.True(t, got.Lines[0].Match, "every line is matched when the query matches none literally")
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	tagresource "github.com/huhouhua/glctl/cmd/resources/tag"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	tagLong = templates.LongDesc(`
+		Tag the repository of a project.
+
+		The tags are listed, created and protected with the get, create and edit
+		commands, this command computes the name of the next one.`)
+
+	tagExample = templates.Examples(`
+		# Tag main with the next version picked from its conventional commits
+		glctl tag bump -p group/myapp --ref=main --auto`)
+)
+
+func NewTagCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "tag",
+		Short:                 "Tag a ref with the next semantic version",
+		Long:                  tagLong,
+		Example:               tagExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(tagresource.NewBumpTagCmd(f, ioStreams))
+	return cmd
+}
@@ -14,9 +14,13 @@
 
 package types
 
+import "time"
+
 type Config struct {
 	OathInfo *GitLabOauthInfo
 	OathEnv  *GitLabOathFormEnv
+	// CacheTTL enables the on-disk response cache when greater than zero.
+	CacheTTL time.Duration `yaml:"-"`
 }
 
 func NewConfig() *Config {
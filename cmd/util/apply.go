@@ -0,0 +1,198 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyFlags are the flags of the commands making the items of a resource
+// match a yaml file.
+type ApplyFlags struct {
+	File   string
+	Prune  bool
+	DryRun bool
+}
+
+// AddApplyVarFlags registers the flags of an apply command, file describes
+// the yaml file and prune what --prune deletes.
+func AddApplyVarFlags(cmd *cobra.Command, p *ApplyFlags, file, prune string) {
+	f := cmd.Flags()
+	f.StringVarP(&p.File, "filename", "f", p.File, file)
+	VerifyMarkFlagRequired(cmd, "filename")
+	f.BoolVar(&p.Prune, "prune", p.Prune, fmt.Sprintf("If true, %s missing from the file.", prune))
+	f.BoolVar(&p.DryRun, "dry-run", p.DryRun, "If true, only print the changes without applying them.")
+}
+
+// ReadApplyFile reads the items listed in the yaml file name, kind names
+// the items in the errors.
+func ReadApplyFile[T any](name, kind string) ([]T, error) {
+	b, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var items []T
+	if err = yaml.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("failed to read the %ss of %s: %w", kind, name, err)
+	}
+	return items, nil
+}
+
+// Change is an item to create, update or delete, Fields describe the
+// change.
+type Change[T any] struct {
+	Action  string
+	Current T
+	Desired T
+	Fields  []string
+}
+
+// Applier plans and applies the changes making the current items of a
+// resource match the desired ones.
+type Applier[T any] struct {
+	// Kind names the items in the messages, e.g. variable, and Target names
+	// what they belong to, e.g. project group/myapp.
+	Kind   string
+	Target string
+	// CreateVerb and DeleteVerb are the actions of the items to create and
+	// to delete, create and delete when empty.
+	CreateVerb string
+	DeleteVerb string
+
+	// ID identifies an item, Diff describes the fields of current which
+	// differ in desired and Describe an item created or deleted, if set.
+	ID       func(item T) string
+	Diff     func(current, desired T) []string
+	Describe func(item T) []string
+
+	Create func(desired T) error
+	Update func(current, desired T) error
+	Delete func(current T) error
+}
+
+// Plan returns the changes turning current into desired, the items missing
+// from desired are only deleted with prune.
+func (a *Applier[T]) Plan(current, desired []T, prune bool) []*Change[T] {
+	existing := map[string]T{}
+	for _, item := range current {
+		existing[a.ID(item)] = item
+	}
+	wanted := map[string]bool{}
+	var changes []*Change[T]
+	for _, item := range desired {
+		wanted[a.ID(item)] = true
+		old, ok := existing[a.ID(item)]
+		if !ok {
+			changes = append(changes, &Change[T]{Action: a.createVerb(), Desired: item, Fields: a.describe(item)})
+			continue
+		}
+		if fields := a.Diff(old, item); len(fields) > 0 {
+			changes = append(changes, &Change[T]{Action: "update", Current: old, Desired: item, Fields: fields})
+		}
+	}
+	if prune {
+		for _, item := range current {
+			if !wanted[a.ID(item)] {
+				changes = append(changes, &Change[T]{Action: a.deleteVerb(), Current: item, Fields: a.describe(item)})
+			}
+		}
+	}
+	return changes
+}
+
+// Run prints the changes turning current into desired and applies them
+// unless flags.DryRun is set.
+func (a *Applier[T]) Run(out io.Writer, current, desired []T, flags ApplyFlags) error {
+	changes := a.Plan(current, desired, flags.Prune)
+	if len(changes) == 0 {
+		_, err := fmt.Fprintf(out, "%ss of %s are up to date, nothing to apply\n", a.Kind, a.Target)
+		return err
+	}
+	printer := NewListPrinter("simple", out, []string{"ACTION", strings.ToUpper(a.Kind), "CHANGES"},
+		func(c *Change[T]) []string {
+			return []string{c.Action, a.id(c), strings.Join(c.Fields, ", ")}
+		})
+	if err := printer.PrintChunk(changes); err != nil {
+		return err
+	}
+	if err := printer.Flush(); err != nil {
+		return err
+	}
+	if flags.DryRun {
+		return nil
+	}
+	counts := map[string]int{}
+	for _, c := range changes {
+		var err error
+		switch c.Action {
+		case a.createVerb():
+			err = a.Create(c.Desired)
+		case a.deleteVerb():
+			err = a.Delete(c.Current)
+		default:
+			err = a.Update(c.Current, c.Desired)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", c.Action, a.Kind, a.id(c), err)
+		}
+		counts[c.Action]++
+	}
+	_, err := fmt.Fprintf(out, "%ss of %s applied: %d %s, %d updated, %d %s\n", a.Kind, a.Target,
+		counts[a.createVerb()], pastTense(a.createVerb()), counts["update"],
+		counts[a.deleteVerb()], pastTense(a.deleteVerb()))
+	return err
+}
+
+// id identifies the item of the change.
+func (a *Applier[T]) id(c *Change[T]) string {
+	if c.Action == a.deleteVerb() {
+		return a.ID(c.Current)
+	}
+	return a.ID(c.Desired)
+}
+
+func (a *Applier[T]) describe(item T) []string {
+	if a.Describe == nil {
+		return nil
+	}
+	return a.Describe(item)
+}
+
+func (a *Applier[T]) createVerb() string {
+	if a.CreateVerb == "" {
+		return "create"
+	}
+	return a.CreateVerb
+}
+
+func (a *Applier[T]) deleteVerb() string {
+	if a.DeleteVerb == "" {
+		return "delete"
+	}
+	return a.DeleteVerb
+}
+
+// pastTense returns the past tense of the regular verb.
+func pastTense(verb string) string {
+	if strings.HasSuffix(verb, "e") {
+		return verb + "d"
+	}
+	return verb + "ed"
+}
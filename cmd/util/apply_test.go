@@ -0,0 +1,140 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// testApplier applies changes of test items to applied, deleting the item
+// named fail fails.
+func testApplier(applied *[]string) *Applier[*testItem] {
+	record := func(action string, item *testItem) error {
+		if item.Name == "fail" {
+			return errors.New("forbidden")
+		}
+		*applied = append(*applied, action+" "+item.Name)
+		return nil
+	}
+	return &Applier[*testItem]{
+		Kind:       "item",
+		Target:     "project group/myapp",
+		CreateVerb: "add",
+		ID:         func(item *testItem) string { return item.Name },
+		Diff: func(current, desired *testItem) []string {
+			if current.Value == desired.Value {
+				return nil
+			}
+			return []string{"value=" + desired.Value}
+		},
+		Describe: func(item *testItem) []string { return []string{"value=" + item.Value} },
+		Create:   func(desired *testItem) error { return record("add", desired) },
+		Update:   func(_, desired *testItem) error { return record("update", desired) },
+		Delete:   func(current *testItem) error { return record("delete", current) },
+	}
+}
+
+func TestApplierPlan(t *testing.T) {
+	current := []*testItem{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}, {Name: "c", Value: "3"}}
+	desired := []*testItem{{Name: "a", Value: "1"}, {Name: "b", Value: "20"}, {Name: "d", Value: "4"}}
+	summary := func(changes []*Change[*testItem]) []string {
+		var s []string
+		for _, c := range changes {
+			s = append(s, c.Action+" "+strings.Join(c.Fields, ","))
+		}
+		return s
+	}
+	a := testApplier(nil)
+	assert.Equal(t, []string{"update value=20", "add value=4"}, summary(a.Plan(current, desired, false)))
+	assert.Equal(t, []string{"update value=20", "add value=4", "delete value=3"},
+		summary(a.Plan(current, desired, true)))
+	assert.Empty(t, a.Plan(current, current, true))
+}
+
+func TestApplierRun(t *testing.T) {
+	current := []*testItem{{Name: "a", Value: "1"}, {Name: "c", Value: "3"}}
+	desired := []*testItem{{Name: "a", Value: "10"}, {Name: "b", Value: "2"}}
+	tests := []struct {
+		name        string
+		current     []*testItem
+		flags       ApplyFlags
+		wantApplied []string
+		wantLast    string
+		wantError   string
+	}{{
+		name:     "up to date",
+		current:  desired,
+		wantLast: "items of project group/myapp are up to date, nothing to apply",
+	}, {
+		name:     "dry run",
+		current:  current,
+		flags:    ApplyFlags{Prune: true, DryRun: true},
+		wantLast: "delete  c     value=3",
+	}, {
+		name:        "apply",
+		current:     current,
+		flags:       ApplyFlags{Prune: true},
+		wantApplied: []string{"update a", "add b", "delete c"},
+		wantLast:    "items of project group/myapp applied: 1 added, 1 updated, 1 deleted",
+	}, {
+		name:        "failed",
+		current:     append(current, &testItem{Name: "fail"}),
+		flags:       ApplyFlags{Prune: true},
+		wantApplied: []string{"update a", "add b", "delete c"},
+		wantLast:    "delete  fail  value=",
+		wantError:   "failed to delete item fail: forbidden",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var applied []string
+			out := &bytes.Buffer{}
+			err := testApplier(&applied).Run(out, tc.current, desired, tc.flags)
+			if tc.wantError != "" {
+				assert.EqualError(t, err, tc.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantApplied, applied)
+			lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+			assert.Equal(t, tc.wantLast, strings.TrimSpace(lines[len(lines)-1]))
+		})
+	}
+}
+
+func TestReadApplyFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "items.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("- name: a\n  value: \"1\"\n"), 0o644))
+	items, err := ReadApplyFile[*testItem](file, "item")
+	assert.NoError(t, err)
+	assert.Equal(t, []*testItem{{Name: "a", Value: "1"}}, items)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalid, []byte("name: a\n"), 0o644))
+	_, err = ReadApplyFile[*testItem](invalid, "item")
+	assert.ErrorContains(t, err, "failed to read the items of "+invalid)
+}
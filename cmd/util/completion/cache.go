@@ -0,0 +1,98 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultCacheTTL is how long completion results are served from disk
+// before the API is queried again.
+const defaultCacheTTL = 2 * time.Minute
+
+// diskCache stores completion candidates as small json files so that
+// repeated <TAB> presses do not hit the API every time.
+type diskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func newDiskCache(dir string, ttl time.Duration) *diskCache {
+	return &diskCache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// get returns the cached values for the key, or false if there is no
+// entry or it is older than the cache ttl.
+func (c *diskCache) get(key ...string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	path := c.path(key...)
+	fi, err := os.Stat(path)
+	if err != nil || time.Since(fi.ModTime()) > c.ttl {
+		return nil, false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var values []string
+	if err = json.Unmarshal(b, &values); err != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+// set writes the values for the key, errors are ignored because the
+// cache is only an optimization.
+func (c *diskCache) set(values []string, key ...string) {
+	if c == nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, ".tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), c.path(key...))
+}
+
+func (c *diskCache) path(key ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(key, "\x00")))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCache(t *testing.T) {
+	cache := newDiskCache(t.TempDir(), time.Minute)
+	_, ok := cache.get("server", "projects", "")
+	assert.False(t, ok)
+
+	cache.set([]string{"Group1/Project1", "Group1/Project2"}, "server", "projects", "")
+	values, ok := cache.get("server", "projects", "")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Group1/Project1", "Group1/Project2"}, values)
+
+	_, ok = cache.get("server", "projects", "Project")
+	assert.False(t, ok)
+
+	expired := time.Now().Add(-2 * time.Minute)
+	assert.NoError(t, os.Chtimes(cache.path("server", "projects", ""), expired, expired))
+	_, ok = cache.get("server", "projects", "")
+	assert.False(t, ok)
+}
+
+func TestFilterPrefix(t *testing.T) {
+	values := []string{"main", "master", "develop", "feature/a"}
+	assert.Equal(t, []string{"main", "master"}, filterPrefix(values, "ma"))
+	assert.Equal(t, values, filterPrefix(values, ""))
+	assert.Nil(t, filterPrefix(values, "release"))
+}
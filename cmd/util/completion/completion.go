@@ -0,0 +1,319 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+// maxCompletionResults limits how many candidates are requested from the
+// server for a single completion.
+const maxCompletionResults = 100
+
+// Func is the signature cobra expects for ValidArgsFunction and
+// RegisterFlagCompletionFunc.
+type Func = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// FirstArg restricts the completion function to the first positional argument.
+func FirstArg(fn Func) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// ProjectAndPathArgs completes a project as the first positional argument
+// and a repository path of that project as the second one.
+func ProjectAndPathArgs(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return ProjectCompletionFunc(f)(cmd, args, toComplete)
+		case 1:
+			return FilePathCompletionFunc(f)(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ProjectCompletionFunc completes project paths visible to the current user.
+func ProjectCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		search := toComplete
+		if i := strings.LastIndex(search, "/"); i >= 0 {
+			search = search[i+1:]
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			projects, _, err := client.Projects.ListProjects(&gitlab.ListProjectsOptions{
+				ListOptions:      gitlab.ListOptions{PerPage: maxCompletionResults},
+				Membership:       pointer.ToBool(true),
+				Simple:           pointer.ToBool(true),
+				Search:           pointer.ToString(search),
+				SearchNamespaces: pointer.ToBool(true),
+				OrderBy:          pointer.ToString("last_activity_at"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			var paths []string
+			for _, p := range projects {
+				paths = append(paths, p.PathWithNamespace)
+			}
+			return paths, nil
+		}, "projects", search)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// GroupCompletionFunc completes group full paths visible to the current user.
+func GroupCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		search := toComplete
+		if i := strings.LastIndex(search, "/"); i >= 0 {
+			search = search[i+1:]
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			groups, _, err := client.Groups.ListGroups(&gitlab.ListGroupsOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+				Search:      pointer.ToString(search),
+			})
+			if err != nil {
+				return nil, err
+			}
+			var paths []string
+			for _, g := range groups {
+				paths = append(paths, g.FullPath)
+			}
+			return paths, nil
+		}, "groups", search)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// BranchCompletionFunc completes branch names of the project selected with
+// --project, or of the project given as the first argument.
+func BranchCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project := projectFromCommand(cmd, args)
+		if project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			opt := &gitlab.ListBranchesOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+			}
+			if toComplete != "" {
+				opt.Search = pointer.ToString("^" + toComplete)
+			}
+			branches, _, err := client.Branches.ListBranches(project, opt)
+			if err != nil {
+				return nil, err
+			}
+			var names []string
+			for _, b := range branches {
+				names = append(names, b.Name)
+			}
+			return names, nil
+		}, "branches", project, toComplete)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// TagCompletionFunc completes the tags of the project selected with --project.
+func TagCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project := projectFromCommand(cmd, nil)
+		if project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			opt := &gitlab.ListTagsOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+			}
+			if toComplete != "" {
+				opt.Search = pointer.ToString("^" + toComplete)
+			}
+			tags, _, err := client.Tags.ListTags(project, opt)
+			if err != nil {
+				return nil, err
+			}
+			var names []string
+			for _, t := range tags {
+				names = append(names, t.Name)
+			}
+			return names, nil
+		}, "tags", project, toComplete)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// MergeRequestCompletionFunc completes the iids of the opened merge requests
+// of the project selected with --project, described by their titles.
+func MergeRequestCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project := projectFromCommand(cmd, nil)
+		if project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			mrs, _, err := client.MergeRequests.ListProjectMergeRequests(project, &gitlab.ListProjectMergeRequestsOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+				State:       pointer.ToString("opened"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			var iids []string
+			for _, mr := range mrs {
+				iids = append(iids, fmt.Sprintf("%d\t%s", mr.IID, mr.Title))
+			}
+			return iids, nil
+		}, "mergerequests", project)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// IssueCompletionFunc completes the iids of the opened issues of the project
+// selected with --project, described by their titles.
+func IssueCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project := projectFromCommand(cmd, nil)
+		if project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			issues, _, err := client.Issues.ListProjectIssues(project, &gitlab.ListProjectIssuesOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+				State:       pointer.ToString("opened"),
+			})
+			if err != nil {
+				return nil, err
+			}
+			var iids []string
+			for _, issue := range issues {
+				iids = append(iids, fmt.Sprintf("%d\t%s", issue.IID, issue.Title))
+			}
+			return iids, nil
+		}, "issues", project)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// FilePathCompletionFunc completes repository tree paths of the selected
+// project one directory level at a time, at the ref given by --ref or --branch.
+func FilePathCompletionFunc(f cmdutil.Factory) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project := projectFromCommand(cmd, args)
+		if project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		dir := ""
+		if i := strings.LastIndex(toComplete, "/"); i >= 0 {
+			dir = toComplete[:i]
+		}
+		ref := refFromCommand(cmd)
+		values := cached(f, func(client *gitlab.Client) ([]string, error) {
+			opt := &gitlab.ListTreeOptions{
+				ListOptions: gitlab.ListOptions{PerPage: maxCompletionResults},
+				Path:        pointer.ToString(dir),
+			}
+			if ref != "" {
+				opt.Ref = pointer.ToString(ref)
+			}
+			tree, _, err := client.Repositories.ListTree(project, opt)
+			if err != nil {
+				return nil, err
+			}
+			var paths []string
+			for _, node := range tree {
+				if node.Type == "tree" {
+					paths = append(paths, path.Clean(node.Path)+"/")
+					continue
+				}
+				paths = append(paths, node.Path)
+			}
+			return paths, nil
+		}, "tree", project, ref, dir)
+		return filterPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// cached returns the values stored for the key of the current user on the
+// current server, and falls back to fetch when they are missing or expired. Errors are swallowed,
+// a failed completion simply offers no candidates.
+func cached(f cmdutil.Factory, fetch func(client *gitlab.Client) ([]string, error), key ...string) []string {
+	client, err := f.GitlabClient()
+	if err != nil {
+		return nil
+	}
+	identity, err := f.Identity()
+	if err != nil {
+		return nil
+	}
+	key = append([]string{identity}, key...)
+	var cache *diskCache
+	if dir, err := cmdutil.CacheDir(); err == nil {
+		cache = newDiskCache(filepath.Join(dir, "completion"), defaultCacheTTL)
+	}
+	if values, ok := cache.get(key...); ok {
+		return values
+	}
+	values, err := fetch(client)
+	if err != nil {
+		return nil
+	}
+	cache.set(values, key...)
+	return values
+}
+
+func projectFromCommand(cmd *cobra.Command, args []string) string {
+	if flag := cmd.Flags().Lookup("project"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+	if len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
+func refFromCommand(cmd *cobra.Command) string {
+	for _, name := range []string{"ref", "branch"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return flag.Value.String()
+		}
+	}
+	return ""
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	var matched []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			matched = append(matched, v)
+		}
+	}
+	return matched
+}
@@ -17,9 +17,11 @@ package util
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/huhouhua/glctl/cmd/types"
 )
@@ -37,6 +39,8 @@ var _ RESTClientGetter = &ConfigFlags{}
 type ConfigFlags struct {
 	Env          *types.GitLabOathFormEnv
 	Oath         *types.GitLabOauthInfo
+	CacheTTL     *time.Duration
+	NoCache      *bool
 	clientConfig ClientConfig
 	lock         sync.Mutex
 	// If set to true, will use persistent client config and
@@ -46,7 +50,24 @@ type ConfigFlags struct {
 }
 
 func (f *ConfigFlags) ToRESTConfig() (*types.Config, error) {
-	return f.ToRawGLConfigLoader().ClientConfig()
+	config, err := f.ToRawGLConfigLoader().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.CacheTTL = f.cacheTTL()
+	return config, nil
+}
+
+// cacheTTL returns the --cache-ttl flag, falling back to the cache_ttl
+// config value. The response cache stays disabled with --no-cache.
+func (f *ConfigFlags) cacheTTL() time.Duration {
+	if f.NoCache != nil && *f.NoCache {
+		return 0
+	}
+	if f.CacheTTL != nil && *f.CacheTTL > 0 {
+		return *f.CacheTTL
+	}
+	return viper.GetDuration("cache_ttl")
 }
 
 // ToRawGLConfigLoader binds config flag values to config overrides
@@ -99,11 +120,20 @@ func NewConfigFlags(usePersistentConfig bool) *ConfigFlags {
 			TokenType:    pointer.ToString(""),
 			UserName:     pointer.ToString(""),
 		},
+		CacheTTL:            pointer.To(time.Duration(0)),
+		NoCache:             pointer.ToBool(false),
 		usePersistentConfig: usePersistentConfig,
 	}
 }
 
 // AddConfig binds client configuration flags to a given flagset.
 func (f *ConfigFlags) AddFlags(flags *pflag.FlagSet) {
-
+	if f.CacheTTL != nil {
+		flags.DurationVar(f.CacheTTL, "cache-ttl", *f.CacheTTL,
+			"Serve GET responses from the on-disk cache for this long before revalidating them "+
+				"with the server. The cache is disabled unless this flag or cache_ttl in the config file is set.")
+	}
+	if f.NoCache != nil {
+		flags.BoolVar(f.NoCache, "no-cache", *f.NoCache, "Bypass the on-disk response cache")
+	}
 }
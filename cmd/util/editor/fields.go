@@ -0,0 +1,165 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package editor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldChange is a field changed through EditFields.
+type FieldChange struct {
+	Name string
+	From interface{}
+	To   interface{}
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Name, formatValue(c.From), formatValue(c.To))
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// EditFields opens the fields of current that options can update as YAML in
+// the editor, fields are matched by their JSON names. Only the changed fields
+// are decoded into options, so they are the only ones sent. A file that can
+// not be decoded is reopened with the error until it is saved unchanged.
+func (e Editor) EditFields(prefix string, current, options interface{}) ([]FieldChange, error) {
+	fields, err := editableFields(current, options)
+	if err != nil {
+		return nil, err
+	}
+	content, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var reason string
+	for {
+		edited, file, err := e.LaunchTempFile(prefix, ".yaml",
+			io.MultiReader(bytes.NewReader(Header(reason, true)), bytes.NewReader(content)))
+		if len(file) > 0 {
+			_ = os.Remove(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		edited = StripHeader(edited)
+		if reason != "" && bytes.Equal(content, edited) {
+			return nil, fmt.Errorf("edit cancelled, %s", reason)
+		}
+		content = edited
+		changes, err := decodeChanges(fields, edited, options)
+		if err != nil {
+			reason = err.Error()
+			continue
+		}
+		return changes, nil
+	}
+}
+
+// editableFields returns the fields of current named like a field of options.
+func editableFields(current, options interface{}) (map[string]interface{}, error) {
+	all, err := normalize(current)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	for _, name := range jsonNames(reflect.TypeOf(options).Elem()) {
+		if v, ok := all[name]; ok {
+			fields[name] = v
+		}
+	}
+	return fields, nil
+}
+
+// decodeChanges decodes the fields of edited that differ from fields into
+// options, options is left untouched on errors.
+func decodeChanges(fields map[string]interface{}, edited []byte, options interface{}) ([]FieldChange, error) {
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(edited, &values); err != nil {
+		return nil, err
+	}
+	values, err := normalize(values)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changed := map[string]interface{}{}
+	var changes []FieldChange
+	for _, name := range names {
+		from, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		if reflect.DeepEqual(from, values[name]) {
+			continue
+		}
+		changed[name] = values[name]
+		changes = append(changes, FieldChange{Name: name, From: from, To: values[name]})
+	}
+	data, err := json.Marshal(changed)
+	if err != nil {
+		return nil, err
+	}
+	decoded := reflect.New(reflect.TypeOf(options).Elem())
+	if err = json.Unmarshal(data, decoded.Interface()); err != nil {
+		return nil, err
+	}
+	reflect.ValueOf(options).Elem().Set(decoded.Elem())
+	return changes, nil
+}
+
+// normalize returns v as the map of its JSON encoding, so values read from
+// YAML compare equal to the ones of the API.
+func normalize(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func jsonNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package editor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type testResource struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	LFSEnabled  bool     `json:"lfs_enabled"`
+	Topics      []string `json:"topics"`
+}
+
+type testOptions struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	LFSEnabled  *bool     `json:"lfs_enabled,omitempty"`
+	Topics      *[]string `json:"topics,omitempty"`
+}
+
+func TestEditFields(t *testing.T) {
+	current := &testResource{ID: 1, Name: "app", Description: "old", Topics: []string{"go"}}
+	edit := Editor{Args: []string{"sed", "-i", "-e", "s/old/new/", "-e", "s/lfs_enabled: false/lfs_enabled: true/"}}
+	options := &testOptions{}
+	changes, err := edit.EditFields("", current, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []FieldChange{
+		{Name: "description", From: "old", To: "new"},
+		{Name: "lfs_enabled", From: false, To: true},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+	if options.Name != nil || options.Topics != nil {
+		t.Errorf("unchanged fields are set: %+v", options)
+	}
+	if options.Description == nil || *options.Description != "new" || options.LFSEnabled == nil || !*options.LFSEnabled {
+		t.Errorf("changed fields are not set: %+v", options)
+	}
+	if got := changes[0].String(); got != `description: "old" -> "new"` {
+		t.Errorf("unexpected summary: %s", got)
+	}
+}
+
+func TestDecodeChanges(t *testing.T) {
+	fields := map[string]interface{}{"name": "app", "lfs_enabled": false}
+	if _, err := decodeChanges(fields, []byte("id: 2\n"), &testOptions{}); err == nil {
+		t.Errorf("expected an error for a field that can't be edited")
+	}
+	options := &testOptions{}
+	if _, err := decodeChanges(fields, []byte("lfs_enabled: maybe\nname: web\n"), options); err == nil {
+		t.Errorf("expected an error for a value of the wrong type")
+	}
+	if options.Name != nil {
+		t.Errorf("options changed on error: %+v", options)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	content := []byte("# my comment\nname: app\n")
+	if len(Header("", false)) != 0 {
+		t.Errorf("expected no header without a reason")
+	}
+	header := Header("invalid YAML: line 1\nsecond line", true)
+	if !bytes.Contains(header, []byte("# error: invalid YAML: line 1\n# error: second line\n")) {
+		t.Errorf("unexpected header: %s", header)
+	}
+	if got := StripHeader(append(header, content...)); !bytes.Equal(got, content) {
+		t.Errorf("unexpected content: %s", got)
+	}
+	if got := StripHeader(content); !bytes.Equal(got, content) {
+		t.Errorf("unexpected content: %s", got)
+	}
+}
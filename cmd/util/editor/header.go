@@ -0,0 +1,56 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package editor
+
+import "bytes"
+
+const (
+	headerStart = "# Please edit the file below, this header is removed when the file is saved."
+	headerEnd   = "# ---"
+)
+
+// Header returns the comment header explaining why a file is reopened in the
+// editor, kubectl edit style. An invalid file is given up when it is saved
+// unchanged. The header is empty without a reason.
+func Header(reason string, invalid bool) []byte {
+	if reason == "" {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(headerStart + "\n")
+	if invalid {
+		buf.WriteString("# Save the file unchanged to abort the edit.\n")
+	}
+	buf.WriteString("#\n")
+	for _, line := range bytes.Split(bytes.TrimRight([]byte(reason), "\n"), []byte("\n")) {
+		buf.WriteString("# error: ")
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	buf.WriteString(headerEnd + "\n")
+	return buf.Bytes()
+}
+
+// StripHeader removes the header added by Header from content.
+func StripHeader(content []byte) []byte {
+	if !bytes.HasPrefix(content, []byte(headerStart+"\n")) {
+		return content
+	}
+	end := bytes.Index(content, []byte("\n"+headerEnd+"\n"))
+	if end < 0 {
+		return content
+	}
+	return content[end+len(headerEnd)+2:]
+}
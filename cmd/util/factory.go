@@ -20,4 +20,8 @@ type Factory interface {
 
 	// GitlabClient gives you back an external gitlabClient
 	GitlabClient() (*gitlab.Client, error)
+
+	// Identity returns a digest of the server and the user the client
+	// authenticates as, the caches of a user are keyed by it.
+	Identity() (string, error)
 }
@@ -50,3 +50,11 @@ func (f *factoryImpl) GitlabClient() (*gitlab.Client, error) {
 	}
 	return NewForConfig(clientConfig)
 }
+
+func (f *factoryImpl) Identity() (string, error) {
+	clientConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return "", err
+	}
+	return newGitLabAuthorization(clientConfig.OathInfo, clientConfig.OathEnv).identityDigest(), nil
+}
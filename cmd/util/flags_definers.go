@@ -107,6 +107,16 @@ func AddVisibilityFlag(cmd *cobra.Command) {
 	cmd.Flags().String("visibility", "private", "public, internal or private")
 }
 
+func AddLimitVarFlag(cmd *cobra.Command, p *int64) {
+	cmd.Flags().Int64Var(p, "limit", *p,
+		"Maximum number of results to return across all pages, 0 means no limit")
+}
+
+func AddChunkSizeVarFlag(cmd *cobra.Command, p *int64) {
+	cmd.Flags().Int64Var(p, "chunk-size", *p,
+		"Return large lists in chunks of this size rather than all at once, used as the page size with --all")
+}
+
 // WarnWordSepNormalizeFunc changes and warns for flags that contain "_" separators.
 func WarnWordSepNormalizeFunc(f *pflag.FlagSet, name string) pflag.NormalizedName {
 	if strings.Contains(name, "_") {
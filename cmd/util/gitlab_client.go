@@ -16,11 +16,14 @@ package util
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 
 	"github.com/huhouhua/glctl/cmd/util/auth"
+	"github.com/huhouhua/glctl/pkg/util/httpcache"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 
@@ -29,26 +32,56 @@ import (
 
 func NewForConfig(config *types.Config) (*gitlab.Client, error) {
 	authorization := newGitLabAuthorization(config.OathInfo, config.OathEnv)
+	cache := withResponseCache(authorization, config.CacheTTL)
 	switch {
 	case authorization.HasPasswordAuth():
 		as := auth.NewPasswordCredentialsAuthSource(*authorization.OathEnv.UserName, *authorization.OathEnv.Password)
-		return gitlab.NewAuthSourceClient(as, gitlab.WithBaseURL(withApiUrl(*authorization.OathEnv.Url)))
+		return gitlab.NewAuthSourceClient(as, gitlab.WithBaseURL(withApiUrl(*authorization.OathEnv.Url)), cache)
 	case authorization.HasBasicAuth():
-		return gitlab.NewClient(*authorization.OathEnv.PrivateToken, gitlab.WithBaseURL(*authorization.OathEnv.Url))
+		return gitlab.NewClient(
+			*authorization.OathEnv.PrivateToken,
+			gitlab.WithBaseURL(*authorization.OathEnv.Url),
+			cache,
+		)
 	case authorization.HasOathAuth():
 		return gitlab.NewAuthSourceClient(gitlab.OAuthTokenSource{TokenSource: oauth2.StaticTokenSource(
 			&oauth2.Token{AccessToken: *authorization.OathEnv.OauthToken},
-		)}, gitlab.WithBaseURL(*authorization.OathEnv.Url))
+		)}, gitlab.WithBaseURL(*authorization.OathEnv.Url), cache)
 	case authorization.HasAuth():
 		return gitlab.NewAuthSourceClient(gitlab.OAuthTokenSource{
 			TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: *authorization.OathInfo.AccessToken}),
-		}, gitlab.WithBaseURL(withApiUrl(*authorization.OathInfo.HostUrl)))
+		}, gitlab.WithBaseURL(withApiUrl(*authorization.OathInfo.HostUrl)), cache)
 	default:
 		return nil, fmt.Errorf("no client was created. "+
 			"gitlab configuration was not set properly. \n %s", "")
 	}
 }
 
+// withResponseCache wraps the client transport with the on-disk response
+// cache, keyed by server and user. It returns nil, which the client skips,
+// when the cache is disabled.
+func withResponseCache(authorization *GitLabAuthorization, ttl time.Duration) gitlab.ClientOptionFunc {
+	if ttl <= 0 {
+		return nil
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return nil
+	}
+	dir = filepath.Join(dir, "http", authorization.identityDigest())
+	return func(c *gitlab.Client) error {
+		httpClient := c.HTTPClient()
+		httpClient.Transport = httpcache.NewTransport(dir, ttl, httpClient.Transport)
+		return nil
+	}
+}
+
+// SkipCache makes a request bypass the response cache, for the requests
+// polling a resource until it changes and the ones downloading files.
+func SkipCache() gitlab.RequestOptionFunc {
+	return gitlab.WithHeader("Cache-Control", "no-cache")
+}
+
 func withApiUrl(url string) string {
 	if strings.HasSuffix(url, "/api") {
 		return fmt.Sprintf("%s/v4", url)
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -158,3 +159,13 @@ func ReadFile(path string) ([]byte, error) {
 	}
 	return os.ReadFile(path)
 }
+
+// CacheDir returns the directory glctl keeps its on-disk caches in,
+// $XDG_CACHE_HOME/glctl or the platform equivalent.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "glctl"), nil
+}
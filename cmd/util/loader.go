@@ -15,6 +15,8 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
 
 	"github.com/AlekSi/pointer"
@@ -72,6 +74,39 @@ func newGitLabAuthorization(info *types.GitLabOauthInfo, env *types.GitLabOathFo
 	}
 }
 
+// Identity returns the server url and the user the client authenticates as.
+// Tokens are never returned as is, only a digest of them.
+func (g *GitLabAuthorization) Identity() (server string, user string) {
+	switch {
+	case g.HasPasswordAuth():
+		return withApiUrl(*g.OathEnv.Url), *g.OathEnv.UserName
+	case g.HasBasicAuth():
+		return *g.OathEnv.Url, digest(*g.OathEnv.PrivateToken)
+	case g.HasOathAuth():
+		return *g.OathEnv.Url, digest(*g.OathEnv.OauthToken)
+	case g.HasAuth():
+		if g.OathInfo.UserName != nil && *g.OathInfo.UserName != "" {
+			return withApiUrl(*g.OathInfo.HostUrl), *g.OathInfo.UserName
+		}
+		return withApiUrl(*g.OathInfo.HostUrl), digest(*g.OathInfo.AccessToken)
+	default:
+		return "", ""
+	}
+}
+
+// identityDigest returns a short digest of the server and the user, which
+// names the cache of the user on the server.
+func (g *GitLabAuthorization) identityDigest() string {
+	server, user := g.Identity()
+	sum := sha256.Sum256([]byte(server + "\x00" + user))
+	return hex.EncodeToString(sum[:8])
+}
+
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
 func (g *GitLabAuthorization) HasAuth() bool {
 	info := g.OathInfo
 	if info == nil {
@@ -0,0 +1,38 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/huhouhua/glctl/cmd/types"
+)
+
+func TestIdentityDigest(t *testing.T) {
+	withToken := func(url, token string) string {
+		return newGitLabAuthorization(nil, &types.GitLabOathFormEnv{
+			Url:          pointer.ToString(url),
+			PrivateToken: pointer.ToString(token),
+		}).identityDigest()
+	}
+	alice := withToken("https://gitlab.example.com/api/v4", "token-alice")
+	assert.Equal(t, alice, withToken("https://gitlab.example.com/api/v4", "token-alice"))
+	// the caches of another user or another server are never shared.
+	assert.NotEqual(t, alice, withToken("https://gitlab.example.com/api/v4", "token-bob"))
+	assert.NotEqual(t, alice, withToken("https://gitlab.other.com/api/v4", "token-alice"))
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// DefaultChunkSize is the page size used to walk all pages of a list.
+const DefaultChunkSize int64 = 100
+
+// ListFunc requests one page of a list, the request options select the page.
+type ListFunc[T any] func(options ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error)
+
+// ListPages hands every page returned by list to fn as soon as it arrives.
+// Keyset pagination is followed through the Link header and offset
+// pagination through X-Next-Page, so it does not rely on empty pages to
+// stop. Only the first page is requested unless all is set, and no more
+// than limit items are handed to fn when limit is positive.
+func ListPages[T any](all bool, limit int64, list ListFunc[T], fn func([]T) error) error {
+	var (
+		next gitlab.RequestOptionFunc
+		seen int64
+	)
+	for {
+		var options []gitlab.RequestOptionFunc
+		if next != nil {
+			options = append(options, next)
+		}
+		items, resp, err := list(options...)
+		if err != nil {
+			return err
+		}
+		if limit > 0 && seen+int64(len(items)) > limit {
+			items = items[:limit-seen]
+		}
+		seen += int64(len(items))
+		if err = fn(items); err != nil {
+			return err
+		}
+		if !all || (limit > 0 && seen >= limit) || resp == nil {
+			return nil
+		}
+		var ok bool
+		if next, ok = gitlab.WithNext(resp); !ok {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestListPages(t *testing.T) {
+	pages := [][]*gitlab.Branch{
+		{{Name: "a"}, {Name: "b"}},
+		{{Name: "c"}, {Name: "d"}},
+		{{Name: "e"}},
+	}
+	list := func(requests *int) ListFunc[*gitlab.Branch] {
+		return func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+			page := *requests
+			*requests++
+			resp := &gitlab.Response{}
+			if page+1 < len(pages) {
+				resp.NextPage = int64(page + 2)
+			}
+			return pages[page], resp, nil
+		}
+	}
+	tests := []struct {
+		name     string
+		all      bool
+		limit    int64
+		want     []string
+		requests int
+	}{{
+		name:     "first page",
+		want:     []string{"a", "b"},
+		requests: 1,
+	}, {
+		name:     "all pages",
+		all:      true,
+		want:     []string{"a", "b", "c", "d", "e"},
+		requests: 3,
+	}, {
+		name:     "limit stops paging",
+		all:      true,
+		limit:    3,
+		want:     []string{"a", "b", "c"},
+		requests: 2,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			var got []string
+			err := ListPages(tc.all, tc.limit, list(&requests), func(branches []*gitlab.Branch) error {
+				for _, b := range branches {
+					got = append(got, b.Name)
+				}
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.requests, requests)
+		})
+	}
+
+	wantErr := errors.New("error from server")
+	err := ListPages(true, 0, func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+		return nil, nil, wantErr
+	}, func([]*gitlab.Branch) error { return nil })
+	assert.Equal(t, wantErr, err)
+}
+
+func TestListPrinterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewBranchPrinter(JSON, &buf)
+	assert.NoError(t, printer.PrintChunk([]*gitlab.Branch{{Name: "a"}}))
+	assert.NoError(t, printer.PrintChunk([]*gitlab.Branch{{Name: "b"}, {Name: "c"}}))
+	assert.NoError(t, printer.Flush())
+
+	var branches []*gitlab.Branch
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &branches))
+	assert.Len(t, branches, 3)
+
+	single, err := json.MarshalIndent([]*gitlab.Branch{{Name: "a"}}, "", " ")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, PrintBranchOut(JSON, &buf, &gitlab.Branch{Name: "a"}))
+	assert.Equal(t, string(single)+"\n", buf.String())
+}
+
+func TestListPrinterSimple(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewBranchPrinter("simple", &buf)
+	assert.NoError(t, printer.PrintChunk([]*gitlab.Branch{{Name: "main"}}))
+	assert.NoError(t, printer.PrintChunk([]*gitlab.Branch{{Name: "develop"}}))
+	assert.NoError(t, printer.Flush())
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("PROTECTED")))
+	assert.Contains(t, buf.String(), "develop")
+
+	buf.Reset()
+	assert.NoError(t, PrintBranchOut("simple", &buf))
+	assert.Contains(t, buf.String(), noResultMsg)
+}
+
+func TestListPrinterAlignsChunks(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := NewListPrinter("simple", out, []string{"NAME", "STATUS"}, func(v [2]string) []string {
+		return v[:]
+	})
+	assert.NoError(t, p.PrintChunk([][2]string{{"group/application", "running"}, {"group/a", "failed"}}))
+	assert.NoError(t, p.PrintChunk([][2]string{{"b", "ok"}, {"group/another-application", "success"}}))
+	assert.NoError(t, p.Flush())
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 5)
+	column := strings.Index(lines[0], "STATUS")
+	for _, line := range lines[1:4] {
+		assert.Equal(t, column, strings.LastIndex(line, strings.Fields(line)[1]), line)
+	}
+	// a cell wider than the first chunk only shifts its own row.
+	assert.Equal(t, []string{"group/another-application", "success"}, strings.Fields(lines[4]))
+}
@@ -20,7 +20,9 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
 	"github.com/olekukonko/tablewriter/tw"
 
 	"github.com/olekukonko/tablewriter"
@@ -34,101 +36,301 @@ var (
 )
 
 func PrintProjectsOut(format string, w io.Writer, projects ...*gitlab.Project) error {
-	switch format {
-	case JSON:
-		return printJSON(w, projects)
-	case YAML:
-		return printYAML(w, projects)
-	default:
-		if len(projects) == 0 {
-			_, err := fmt.Fprintln(w, noResultMsg)
-			return err
-		}
-		header := []string{"ID", "PATH", "URL", "ISSUES COUNT", "TAGS"}
-		var rows [][]string
-		for _, v := range projects {
-			rows = append(rows, []string{
-				strconv.FormatInt(v.ID, 10),
-				v.PathWithNamespace,
-				v.HTTPURLToRepo,
-				strconv.FormatInt(v.OpenIssuesCount, 10),
-				strings.Join(v.Topics, ","),
-			})
+	return printList(NewProjectsPrinter(format, w), projects)
+}
+
+// NewProjectsPrinter returns a printer streaming projects in the given format.
+func NewProjectsPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Project] {
+	header := []string{"ID", "PATH", "URL", "ISSUES COUNT", "TAGS"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Project) []string {
+		return []string{
+			strconv.FormatInt(v.ID, 10),
+			v.PathWithNamespace,
+			v.HTTPURLToRepo,
+			strconv.FormatInt(v.OpenIssuesCount, 10),
+			strings.Join(v.Topics, ","),
 		}
-		return printTable(header, w, rows)
-	}
+	})
 }
 
 func PrintGroupsOut(format string, w io.Writer, groups ...*gitlab.Group) error {
-	switch format {
-	case JSON:
-		return printJSON(w, groups)
-	case YAML:
-		return printYAML(w, groups)
-	default:
-		if len(groups) == 0 {
-			_, err := fmt.Fprintln(w, noResultMsg)
-			return err
+	return printList(NewGroupsPrinter(format, w), groups)
+}
+
+// NewGroupsPrinter returns a printer streaming groups in the given format.
+func NewGroupsPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Group] {
+	header := []string{"ID", "PATH", "URL", "PARENT ID"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Group) []string {
+		return []string{
+			strconv.FormatInt(v.ID, 10),
+			v.FullPath,
+			v.WebURL,
+			strconv.FormatInt(v.ParentID, 10),
 		}
-		header := []string{"ID", "PATH", "URL", "PARENT ID"}
-		var rows [][]string
-		for _, v := range groups {
-			rows = append(rows, []string{
-				strconv.FormatInt(v.ID, 10),
-				v.FullPath,
-				v.WebURL,
-				strconv.FormatInt(v.ParentID, 10),
-			})
+	})
+}
+
+func PrintBranchOut(format string, w io.Writer, branches ...*gitlab.Branch) error {
+	return printList(NewBranchPrinter(format, w), branches)
+}
+
+// NewBranchPrinter returns a printer streaming branches in the given format.
+func NewBranchPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Branch] {
+	header := []string{"NAME", "PROTECTED", "DEVELOPERS CAN PUSH", "DEVELOPERS CAN MERGE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Branch) []string {
+		return []string{
+			v.Name,
+			strconv.FormatBool(v.Protected),
+			strconv.FormatBool(v.DevelopersCanPush),
+			strconv.FormatBool(v.DevelopersCanMerge),
+		}
+	})
+}
+
+func PrintTagsOut(format string, w io.Writer, tags ...*gitlab.Tag) error {
+	return printList(NewTagsPrinter(format, w), tags)
+}
+
+// NewTagsPrinter returns a printer streaming tags in the given format.
+func NewTagsPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Tag] {
+	header := []string{"NAME", "COMMIT", "PROTECTED", "MESSAGE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Tag) []string {
+		commit := ""
+		if v.Commit != nil {
+			commit = v.Commit.ShortID
+		}
+		message, _, _ := strings.Cut(v.Message, "\n")
+		return []string{
+			v.Name,
+			commit,
+			strconv.FormatBool(v.Protected),
+			message,
+		}
+	})
+}
+
+func PrintReleasesOut(format string, w io.Writer, releases ...*gitlab.Release) error {
+	return printList(NewReleasesPrinter(format, w), releases)
+}
+
+// NewReleasesPrinter returns a printer streaming releases in the given format.
+func NewReleasesPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Release] {
+	header := []string{"TAG", "NAME", "RELEASED", "AUTHOR", "ASSETS"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Release) []string {
+		released := ""
+		if v.ReleasedAt != nil {
+			released = v.ReleasedAt.Format(time.DateTime)
+		}
+		return []string{
+			v.TagName,
+			v.Name,
+			released,
+			v.Author.Username,
+			strconv.Itoa(len(v.Assets.Links)),
+		}
+	})
+}
+
+func PrintFilesOut(format string, w io.Writer, trees ...*gitlab.TreeNode) error {
+	return printList(NewFilesPrinter(format, w), trees)
+}
+
+// NewFilesPrinter returns a printer streaming repository tree nodes in the given format.
+func NewFilesPrinter(format string, w io.Writer) *ListPrinter[*gitlab.TreeNode] {
+	header := []string{"PATH", "TYPE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.TreeNode) []string {
+		return []string{
+			v.Path,
+			v.Type,
+		}
+	})
+}
+
+// NewCommitsPrinter returns a printer streaming commits in the given format.
+func NewCommitsPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Commit] {
+	header := []string{"ID", "DATE", "AUTHOR", "TITLE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Commit) []string {
+		date := ""
+		if v.CommittedDate != nil {
+			date = v.CommittedDate.Format(time.DateTime)
+		}
+		return []string{
+			v.ShortID,
+			date,
+			v.AuthorName,
+			v.Title,
+		}
+	})
+}
+
+func PrintIssuesOut(format string, w io.Writer, issues ...*gitlab.Issue) error {
+	return printList(NewIssuesPrinter(format, w), issues)
+}
+
+// NewIssuesPrinter returns a printer streaming issues in the given format.
+func NewIssuesPrinter(format string, w io.Writer) *ListPrinter[*gitlab.Issue] {
+	header := []string{"REFERENCE", "STATE", "AUTHOR", "TITLE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.Issue) []string {
+		author := ""
+		if v.Author != nil {
+			author = v.Author.Username
+		}
+		return []string{
+			reference(v.References, "#", v.IID),
+			v.State,
+			author,
+			v.Title,
+		}
+	})
+}
+
+// NewPipelinesPrinter returns a printer streaming pipelines in the given format.
+func NewPipelinesPrinter(format string, w io.Writer) *ListPrinter[*gitlab.PipelineInfo] {
+	header := []string{"ID", "STATUS", "REF", "SOURCE", "CREATED"}
+	return NewListPrinter(format, w, header, func(v *gitlab.PipelineInfo) []string {
+		created := ""
+		if v.CreatedAt != nil {
+			created = v.CreatedAt.Format(time.DateTime)
+		}
+		return []string{
+			strconv.FormatInt(v.ID, 10),
+			v.Status,
+			v.Ref,
+			v.Source,
+			created,
 		}
-		return printTable(header, w, rows)
+	})
+}
+
+func PrintMergeRequestsOut(format string, w io.Writer, mergeRequests ...*gitlab.BasicMergeRequest) error {
+	return printList(NewMergeRequestsPrinter(format, w), mergeRequests)
+}
+
+// NewMergeRequestsPrinter returns a printer streaming merge requests in the given format.
+func NewMergeRequestsPrinter(format string, w io.Writer) *ListPrinter[*gitlab.BasicMergeRequest] {
+	header := []string{"REFERENCE", "STATE", "AUTHOR", "BRANCHES", "TITLE"}
+	return NewListPrinter(format, w, header, func(v *gitlab.BasicMergeRequest) []string {
+		author := ""
+		if v.Author != nil {
+			author = v.Author.Username
+		}
+		return []string{
+			reference(v.References, "!", v.IID),
+			v.State,
+			author,
+			v.SourceBranch + " -> " + v.TargetBranch,
+			v.Title,
+		}
+	})
+}
+
+// reference returns the full reference of an issue or a merge request, which
+// names its project, or the short one made of prefix and iid.
+func reference(references *gitlab.IssueReferences, prefix string, iid int64) string {
+	if references != nil && references.Full != "" {
+		return references.Full
 	}
+	return prefix + strconv.FormatInt(iid, 10)
 }
-func PrintBranchOut(format string, w io.Writer, branches ...*gitlab.Branch) error {
-	switch format {
-	case YAML:
-		return printYAML(w, branches)
+
+// ListPrinter prints a list chunk by chunk as the pages arrive from the
+// server, so that the whole list never has to be held in memory. The table
+// header is only written before the first chunk, whose column widths are kept
+// for the chunks after it so that the table stays aligned, and json output is
+// kept a single valid array across chunks.
+type ListPrinter[T any] struct {
+	format string
+	w      io.Writer
+	header []string
+	row    func(T) []string
+	count  int
+	widths []int
+}
+
+// NewListPrinter returns a ListPrinter using header and row for the simple format.
+func NewListPrinter[T any](format string, w io.Writer, header []string, row func(T) []string) *ListPrinter[T] {
+	return &ListPrinter[T]{
+		format: format,
+		w:      w,
+		header: header,
+		row:    row,
+	}
+}
+
+// PrintChunk writes the items following the ones printed before.
+func (p *ListPrinter[T]) PrintChunk(items []T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	switch p.format {
 	case JSON:
-		return printJSON(w, branches)
-	default:
-		if len(branches) == 0 {
-			_, err := fmt.Fprintln(w, noResultMsg)
-			return err
+		for _, item := range items {
+			b, err := json.MarshalIndent(item, " ", " ")
+			if err != nil {
+				return fmt.Errorf("failed printing to json: %w", err)
+			}
+			sep := ",\n"
+			if p.count == 0 {
+				sep = "[\n"
+			}
+			if _, err = fmt.Fprint(p.w, sep, " ", string(b)); err != nil {
+				return err
+			}
+			p.count++
 		}
-		header := []string{"NAME", "PROTECTED", "DEVELOPERS CAN PUSH", "DEVELOPERS CAN MERGE"}
+		return nil
+	case YAML:
+		b, err := yaml.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("failed printing to yaml: %w", err)
+		}
+		p.count += len(items)
+		_, err = fmt.Fprint(p.w, string(b))
+		return err
+	default:
 		var rows [][]string
-		for _, v := range branches {
-			rows = append(rows, []string{
-				v.Name,
-				strconv.FormatBool(v.Protected),
-				strconv.FormatBool(v.DevelopersCanPush),
-				strconv.FormatBool(v.DevelopersCanMerge),
-			})
+		for _, v := range items {
+			rows = append(rows, p.row(v))
 		}
-		return printTable(header, w, rows)
+		if p.count > 0 {
+			p.count += len(items)
+			return printRows(p.w, p.widths, rows)
+		}
+		p.count += len(items)
+		p.widths = columnWidths(p.header, rows)
+		return printTable(p.header, p.w, rows)
 	}
 }
 
-func PrintFilesOut(format string, w io.Writer, trees ...*gitlab.TreeNode) error {
-	switch format {
+// Flush terminates the list, it must be called once after the last chunk.
+func (p *ListPrinter[T]) Flush() error {
+	var err error
+	switch p.format {
 	case JSON:
-		return printJSON(w, trees)
+		if p.count == 0 {
+			_, err = fmt.Fprintln(p.w, "[]")
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, "\n]")
 	case YAML:
-		return printYAML(w, trees)
-	default:
-		if len(trees) == 0 {
-			_, err := fmt.Fprintln(w, noResultMsg)
+		if p.count == 0 {
+			_, err = fmt.Fprintln(p.w, "[]")
 			return err
 		}
-		header := []string{"PATH", "TYPE"}
-		var rows [][]string
-		for _, v := range trees {
-			rows = append(rows, []string{
-				v.Path,
-				v.Type,
-			})
+		_, err = fmt.Fprintln(p.w)
+	default:
+		if p.count == 0 {
+			_, err = fmt.Fprintln(p.w, noResultMsg)
 		}
-		return printTable(header, w, rows)
 	}
+	return err
+}
+
+func printList[T any](p *ListPrinter[T], items []T) error {
+	if err := p.PrintChunk(items); err != nil {
+		return err
+	}
+	return p.Flush()
 }
 
 func printJSON(w io.Writer, v interface{}) error {
@@ -149,13 +351,72 @@ func printYAML(w io.Writer, v interface{}) error {
 	return err
 }
 
+// PrintMatrix prints a table with one row per name in rows and one column per
+// name in columns, cell returns the value at their intersection.
+func PrintMatrix(w io.Writer, corner string, rows, columns []string, cell func(row, column string) string) error {
+	header := append([]string{corner}, columns...)
+	var cells [][]string
+	for _, row := range rows {
+		line := []string{row}
+		for _, column := range columns {
+			line = append(line, cell(row, column))
+		}
+		cells = append(cells, line)
+	}
+	// the column names are values such as branch names, keep them as they are.
+	return renderTable(header, w, cells, tablewriter.WithHeaderAutoFormat(tw.Off))
+}
+
+// columnWidths returns the width of each column of a table, which is the one
+// of its widest cell.
+func columnWidths(header []string, rows [][]string) []int {
+	var widths []int
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], twwidth.Width(cell))
+		}
+	}
+	return widths
+}
+
+// printRows writes rows laid out like the ones of renderTable, each cell
+// padded to the width of its column. A cell wider than its column only shifts
+// the cells after it in the same row.
+func printRows(w io.Writer, widths []int, rows [][]string) error {
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			b.WriteString(" ")
+			b.WriteString(cell)
+			if i < len(widths) {
+				b.WriteString(strings.Repeat(" ", max(widths[i]-twwidth.Width(cell), 0)))
+			}
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
 func printTable(header []string, w io.Writer, rows [][]string) error {
 	if len(header) > 5 {
 		panic("maximum allowed length of a table header is only 5.")
 	}
-	table := tablewriter.NewTable(w,
+	return renderTable(header, w, rows)
+}
+
+func renderTable(header []string, w io.Writer, rows [][]string, opts ...tablewriter.Option) error {
+	alignment := make(tw.Alignment, max(len(header), 3))
+	for i := range alignment {
+		alignment[i] = tw.AlignLeft
+	}
+	opts = append([]tablewriter.Option{
 		tablewriter.WithTrimSpace(tw.Off),
-		tablewriter.WithAlignment(tw.Alignment{tw.AlignLeft, tw.AlignLeft, tw.AlignLeft}),
+		tablewriter.WithAlignment(alignment),
 		tablewriter.WithRendition(tw.Rendition{
 			Borders: tw.BorderNone,
 			Settings: tw.Settings{
@@ -170,8 +431,11 @@ func printTable(header []string, w io.Writer, rows [][]string) error {
 				},
 			},
 		}),
-	)
-	table.Header(header)
+	}, opts...)
+	table := tablewriter.NewTable(w, opts...)
+	if header != nil {
+		table.Header(header)
+	}
 	if err := table.Bulk(rows); err != nil {
 		return err
 	}
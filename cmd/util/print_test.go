@@ -0,0 +1,41 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintMatrix(t *testing.T) {
+	out := &bytes.Buffer{}
+	cells := map[string]string{"group/a main": "updated", "group/b develop": "failed"}
+	err := PrintMatrix(out, "PROJECT", []string{"group/a", "group/b"}, []string{"main", "develop"},
+		func(row, column string) string {
+			if cell, ok := cells[row+" "+column]; ok {
+				return cell
+			}
+			return "-"
+		})
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"PROJECT", "main", "develop"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"group/a", "updated", "-"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"group/b", "-", "failed"}, strings.Fields(lines[2]))
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	cmdutil "github.com/huhouhua/glctl/cmd/util"
 )
@@ -69,6 +70,21 @@ func ValidateFlagStringValue(stringSlice []string,
 	)
 }
 
+// ValidateInteractiveFlags makes sure no field flag is combined with
+// --interactive, the fields are edited in the editor then.
+func ValidateInteractiveFlags(cmd *cobra.Command) error {
+	var changed []string
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed && f.Name != "interactive" {
+			changed = append(changed, "--"+f.Name)
+		}
+	})
+	if len(changed) > 0 {
+		return cmdutil.UsageErrorf(cmd, "--interactive can not be combined with %s", strings.Join(changed, ", "))
+	}
+	return nil
+}
+
 func VerifyMarkFlagRequired(cmd *cobra.Command, fName string) {
 	if err := cmd.MarkFlagRequired(fName); err != nil {
 		glog.Fatalf("error marking %s flag as required for command %s: %v",
@@ -0,0 +1,50 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/huhouhua/glctl/pkg/cli/genericiooptions"
+	"github.com/huhouhua/glctl/pkg/util/templates"
+
+	"github.com/huhouhua/glctl/cmd/resources/pipeline"
+	cmdutil "github.com/huhouhua/glctl/cmd/util"
+)
+
+var (
+	waitLong = templates.LongDesc(`
+		Wait until a resource reaches a final state.
+
+		The command polls the server and exits with a non-zero code when the
+		resource fails or the timeout expires, so that scripts can rely on it.`)
+
+	waitExample = templates.Examples(`
+		# Wait up to 30 minutes for a pipeline to succeed
+		glctl wait pipeline 4211 -p group/myapp --timeout=30m`)
+)
+
+func NewWaitCmd(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "wait",
+		Short:                 "Wait until a resource finishes",
+		Long:                  waitLong,
+		Example:               waitExample,
+		DisableFlagsInUseLine: true,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+	cmd.AddCommand(pipeline.NewWaitPipelineCmd(f, ioStreams))
+	return cmd
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive extracts tar streams and zip files below a destination
+// directory without letting any entry escape it.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RenameFunc maps the name of an archive entry to the slash separated path
+// it is extracted to, entries are skipped when it returns false.
+type RenameFunc func(name string) (string, bool)
+
+// StripComponents returns a RenameFunc dropping the first n path elements of
+// every entry, like tar --strip-components.
+func StripComponents(n int) RenameFunc {
+	return func(name string) (string, bool) {
+		parts := strings.Split(strings.Trim(name, "/"), "/")
+		if len(parts) <= n {
+			return "", false
+		}
+		return strings.Join(parts[n:], "/"), true
+	}
+}
+
+// Untar extracts the tar stream r below dest. Entries are renamed with
+// rename when it is not nil, and an entry whose path or link target would
+// end up outside dest fails the extraction.
+func Untar(r io.Reader, dest string, rename RenameFunc) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := hdr.Name
+		if rename != nil {
+			var ok bool
+			if name, ok = rename(name); !ok {
+				continue
+			}
+		}
+		target, err := securePath(dest, name)
+		if err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode().Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0o755)
+		case tar.TypeReg:
+			err = writeFile(target, tr, mode)
+		case tar.TypeSymlink:
+			err = symlink(dest, target, hdr.Linkname)
+		default:
+			// pax headers and special files carry nothing to extract.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// securePath joins name to dest and makes sure the result stays below dest.
+// The directories between dest and the result must not be links, as an entry
+// written through a link, which an earlier entry may have created, lands
+// wherever the link points to.
+func securePath(dest, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("archive entry %s has an absolute path", name)
+	}
+	target := filepath.Join(dest, filepath.FromSlash(name))
+	if !within(dest, target) {
+		return "", fmt.Errorf("archive entry %s escapes the destination %s", name, dest)
+	}
+	dir := dest
+	rel, _ := filepath.Rel(dest, filepath.Dir(target))
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "." {
+			continue
+		}
+		dir = filepath.Join(dir, part)
+		fi, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("archive entry %s is written through the link %s", name, dir)
+		}
+	}
+	return target, nil
+}
+
+func within(dest, target string) bool {
+	rel, err := filepath.Rel(dest, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	// a link left by an earlier entry is replaced instead of written through.
+	if fi, err := os.Lstat(target); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+		if err = os.Remove(target); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	// the umask applies on create, chmod keeps the executable bit of the entry.
+	return os.Chmod(target, mode)
+}
+
+func symlink(dest, target, link string) error {
+	resolved := link
+	if !filepath.IsAbs(link) {
+		resolved = filepath.Join(filepath.Dir(target), link)
+	}
+	if filepath.IsAbs(link) || !within(dest, resolved) {
+		return fmt.Errorf("archive link %s points outside the destination %s", link, dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	_ = os.Remove(target)
+	return os.Symlink(link, target)
+}
+
+// Unzip extracts the zip file name below dest, entries are renamed and
+// guarded the same way Untar does.
+func Unzip(name, dest string, rename RenameFunc) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		entry := f.Name
+		if rename != nil {
+			var ok bool
+			if entry, ok = rename(entry); !ok {
+				continue
+			}
+		}
+		target, err := securePath(dest, entry)
+		if err != nil {
+			return err
+		}
+		if err = unzipFile(f, dest, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyZip reads every entry of the zip file name, so that a truncated
+// archive or an entry not matching its checksum is reported before the
+// archive is used.
+func VerifyZip(name string) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if err = verifyZipFile(f); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func verifyZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(io.Discard, rc)
+	return err
+}
+
+func unzipFile(f *zip.File, dest, target string) error {
+	if f.FileInfo().IsDir() {
+		return os.MkdirAll(target, 0o755)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	mode := f.Mode()
+	if mode&os.ModeSymlink != 0 {
+		link, err := io.ReadAll(rc)
+		if err != nil {
+			return err
+		}
+		return symlink(dest, target, string(link))
+	}
+	if mode.Perm() == 0 {
+		mode = 0o644
+	}
+	return writeFile(target, rc, mode.Perm())
+}
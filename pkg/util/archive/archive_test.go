@@ -0,0 +1,210 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	name string
+	link string
+	body string
+	mode int64
+}
+
+func tarball(t *testing.T, entries ...entry) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: e.mode, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.link != "" {
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, e.link, 0
+		}
+		assert.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.body))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	return buf
+}
+
+func TestUntar(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []entry
+		rename    RenameFunc
+		files     map[string]string
+		wantError string
+	}{{
+		name: "extract with stripped components",
+		entries: []entry{
+			{name: "project-main-1a2b/app.yaml", body: "app", mode: 0o644},
+			{name: "project-main-1a2b/bin/run.sh", body: "run", mode: 0o755},
+		},
+		rename: StripComponents(1),
+		files:  map[string]string{"app.yaml": "app", "bin/run.sh": "run"},
+	}, {
+		name:      "reject parent traversal",
+		entries:   []entry{{name: "../evil", body: "x", mode: 0o644}},
+		wantError: "escapes the destination",
+	}, {
+		name:      "reject absolute path",
+		entries:   []entry{{name: "/etc/evil", body: "x", mode: 0o644}},
+		wantError: "absolute path",
+	}, {
+		name:      "reject escaping link",
+		entries:   []entry{{name: "link", link: "../../etc/passwd"}},
+		wantError: "points outside the destination",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dest := t.TempDir()
+			err := Untar(tarball(t, tc.entries...), dest, tc.rename)
+			if tc.wantError != "" {
+				assert.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			for name, body := range tc.files {
+				b, err := os.ReadFile(filepath.Join(dest, name))
+				assert.NoError(t, err)
+				assert.Equal(t, body, string(b))
+			}
+			fi, err := os.Stat(filepath.Join(dest, "bin", "run.sh"))
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o755), fi.Mode().Perm())
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []string
+		wantError string
+	}{{
+		name:    "extract with stripped components",
+		entries: []string{"project-main-1a2b/app.yaml", "project-main-1a2b/bin/run.sh"},
+	}, {
+		name:      "reject parent traversal",
+		entries:   []string{"project-main-1a2b/../../evil"},
+		wantError: "escapes the destination",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "archive.zip")
+			f, err := os.Create(name)
+			assert.NoError(t, err)
+			zw := zip.NewWriter(f)
+			for _, entry := range tc.entries {
+				w, err := zw.Create(entry)
+				assert.NoError(t, err)
+				_, err = w.Write([]byte(entry))
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, zw.Close())
+			assert.NoError(t, f.Close())
+
+			dest := t.TempDir()
+			err = Unzip(name, dest, StripComponents(1))
+			if tc.wantError != "" {
+				assert.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			b, err := os.ReadFile(filepath.Join(dest, "bin", "run.sh"))
+			assert.NoError(t, err)
+			assert.Equal(t, "project-main-1a2b/bin/run.sh", string(b))
+		})
+	}
+}
+
+func TestChainedLinks(t *testing.T) {
+	// a -> . is harmless on its own, but a/b -> .. then makes b the parent of
+	// the destination, and b/evil a file next to it.
+	entries := []entry{
+		{name: "a", link: "."},
+		{name: "a/b", link: ".."},
+		{name: "b/evil", body: "x", mode: 0o644},
+	}
+	extract := map[string]func(t *testing.T, dest string) error{
+		"tar": func(t *testing.T, dest string) error {
+			return Untar(tarball(t, entries...), dest, nil)
+		},
+		"zip": func(t *testing.T, dest string) error {
+			name := filepath.Join(t.TempDir(), "archive.zip")
+			f, err := os.Create(name)
+			assert.NoError(t, err)
+			zw := zip.NewWriter(f)
+			for _, e := range entries {
+				hdr := &zip.FileHeader{Name: e.name}
+				hdr.SetMode(os.FileMode(e.mode))
+				body := e.body
+				if e.link != "" {
+					hdr.SetMode(os.ModeSymlink | 0o777)
+					body = e.link
+				}
+				w, err := zw.CreateHeader(hdr)
+				assert.NoError(t, err)
+				_, err = w.Write([]byte(body))
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, zw.Close())
+			assert.NoError(t, f.Close())
+			return Unzip(name, dest, nil)
+		},
+	}
+	for format, fn := range extract {
+		t.Run(format, func(t *testing.T) {
+			parent := t.TempDir()
+			dest := filepath.Join(parent, "dest")
+			assert.NoError(t, os.Mkdir(dest, 0o755))
+			assert.ErrorContains(t, fn(t, dest), "is written through the link")
+			assert.NoFileExists(t, filepath.Join(parent, "evil"))
+		})
+	}
+}
+
+func TestVerifyZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "dist/app", Method: zip.Store})
+	assert.NoError(t, err)
+	_, err = w.Write([]byte("binary"))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.zip")
+	assert.NoError(t, os.WriteFile(valid, buf.Bytes(), 0o644))
+	assert.NoError(t, VerifyZip(valid))
+
+	corrupt := filepath.Join(dir, "corrupt.zip")
+	b := bytes.Replace(buf.Bytes(), []byte("binary"), []byte("binarY"), 1)
+	assert.NoError(t, os.WriteFile(corrupt, b, 0o644))
+	assert.ErrorIs(t, VerifyZip(corrupt), zip.ErrChecksum)
+
+	truncated := filepath.Join(dir, "truncated.zip")
+	assert.NoError(t, os.WriteFile(truncated, buf.Bytes()[:buf.Len()/2], 0o644))
+	assert.ErrorIs(t, VerifyZip(truncated), zip.ErrFormat)
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff renders unified diffs, colored when the output supports it.
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Unified returns the unified diff turning a into b with three lines of
+// context, it is empty when both are equal.
+func Unified(a, b []byte, from, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(a),
+		B:        diffLines(b),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+}
+
+// splitLines splits s into lines keeping their endings, a missing final
+// newline is added so the last line compares equal either way.
+func splitLines(s []byte) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(s), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// noNewline marks a last line without a newline, the way git does.
+const noNewline = "\\ No newline at end of file\n"
+
+// diffLines splits s like splitLines, but marks a missing final newline so
+// that a change of only the final newline shows in the diff.
+func diffLines(s []byte) []string {
+	lines := splitLines(s)
+	if len(s) > 0 && s[len(s)-1] != '\n' {
+		lines[len(lines)-1] += noNewline
+	}
+	return lines
+}
+
+var (
+	headerColor  = color.New(color.Bold)
+	hunkColor    = color.New(color.FgCyan)
+	addedColor   = color.New(color.FgGreen)
+	removedColor = color.New(color.FgRed)
+)
+
+// Fprint writes the unified diff text to w, coloring file headers, hunk
+// headers, added and removed lines.
+func Fprint(w io.Writer, text string) error {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if err := FprintLine(w, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// FprintLine writes a single line of a unified diff to w, colored as Fprint
+// does.
+func FprintLine(w io.Writer, line string) error {
+	var err error
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		_, err = headerColor.Fprintln(w, line)
+	case strings.HasPrefix(line, "@@"):
+		_, err = hunkColor.Fprintln(w, line)
+	case strings.HasPrefix(line, "+"):
+		_, err = addedColor.Fprintln(w, line)
+	case strings.HasPrefix(line, "-"):
+		_, err = removedColor.Fprintln(w, line)
+	default:
+		_, err = fmt.Fprintln(w, line)
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{{
+		name: "equal",
+		a:    "a\nb\n",
+		b:    "a\nb\n",
+		want: "",
+	}, {
+		name: "changed line",
+		a:    "env: dev\nimage: app:v1\n",
+		b:    "env: dev\nimage: app:v2\n",
+		want: "--- a/app.yaml\n+++ b/app.yaml\n@@ -1,2 +1,2 @@\n env: dev\n-image: app:v1\n+image: app:v2\n",
+	}, {
+		name: "new file",
+		a:    "",
+		b:    "env: dev",
+		want: "--- a/app.yaml\n+++ b/app.yaml\n@@ -0,0 +1 @@\n+env: dev\n\\ No newline at end of file\n",
+	}, {
+		name: "final newline removed",
+		a:    "env: dev\nimage: app:v1\n",
+		b:    "env: dev\nimage: app:v1",
+		want: "--- a/app.yaml\n+++ b/app.yaml\n@@ -1,2 +1,2 @@\n env: dev\n-image: app:v1\n" +
+			"+image: app:v1\n\\ No newline at end of file\n",
+	}, {
+		name: "final newline added",
+		a:    "env: dev",
+		b:    "env: dev\n",
+		want: "--- a/app.yaml\n+++ b/app.yaml\n@@ -1 +1 @@\n-env: dev\n\\ No newline at end of file\n+env: dev\n",
+	}, {
+		name: "equal without final newline",
+		a:    "env: dev",
+		b:    "env: dev",
+		want: "",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Unified([]byte(tc.a), []byte(tc.b), "a/app.yaml", "b/app.yaml")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+	out := &bytes.Buffer{}
+	assert.NoError(t, Fprint(out, "--- a\n+++ b\n@@ -1 +1 @@\n-old\n+new\n same\n"))
+	assert.Contains(t, out.String(), "\x1b[31m-old")
+	assert.Contains(t, out.String(), "\x1b[32m+new")
+	assert.Contains(t, out.String(), "\n same\n")
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// Walk calls fn with every line of the hunks of a unified diff, along with
+// the number of the line in the old and in the new file. A number is zero
+// when the line is not part of that side: added lines have no old number,
+// removed lines no new number, and header lines neither.
+func Walk(text string, fn func(line string, oldLine, newLine int64) error) error {
+	var oldLine, newLine int64
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		var err error
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkHeader.FindStringSubmatch(line); m != nil {
+				oldLine, _ = strconv.ParseInt(m[1], 10, 64)
+				newLine, _ = strconv.ParseInt(m[2], 10, 64)
+			}
+			err = fn(line, 0, 0)
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, `\`):
+			err = fn(line, 0, 0)
+		case strings.HasPrefix(line, "+"):
+			err = fn(line, 0, newLine)
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			err = fn(line, oldLine, 0)
+			oldLine++
+		default:
+			err = fn(line, oldLine, newLine)
+			oldLine++
+			newLine++
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	text := "@@ -3,4 +3,5 @@ kind: app\n" +
+		" env: dev\n" +
+		"-image: app:v1\n" +
+		"+image: app:v2\n" +
+		"+replicas: 2\n" +
+		" port: 80\n" +
+		"\\ No newline at end of file\n" +
+		"@@ -20 +21 @@\n" +
+		"-a\n" +
+		"+b\n"
+	var got []string
+	err := Walk(text, func(line string, oldLine, newLine int64) error {
+		got = append(got, fmt.Sprintf("%d %d %s", oldLine, newLine, line))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"0 0 @@ -3,4 +3,5 @@ kind: app",
+		"3 3  env: dev",
+		"4 0 -image: app:v1",
+		"0 4 +image: app:v2",
+		"0 5 +replicas: 2",
+		"5 6  port: 80",
+		"0 0 \\ No newline at end of file",
+		"0 0 @@ -20 +21 @@",
+		"20 0 -a",
+		"0 21 +b",
+	}, got)
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	markerMine   = "<<<<<<<"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>>"
+)
+
+// Merge3 merges the changes made to base in mine and theirs line by line,
+// like git merge-file. Overlapping changes that differ are kept in the result
+// between conflict markers labeled mineLabel and theirsLabel, conflicts
+// reports whether there are any.
+func Merge3(base, mine, theirs []byte, mineLabel, theirsLabel string) (merged []byte, conflicts bool) {
+	o, a, b := splitLines(base), splitLines(mine), splitLines(theirs)
+	inA, inB := matches(o, a), matches(o, b)
+	out := &bytes.Buffer{}
+	write := func(lines []string) {
+		for _, line := range lines {
+			out.WriteString(line)
+		}
+	}
+	i, ia, ib := 0, 0, 0
+	for {
+		// a base line kept at the current position of both sides is stable.
+		if i < len(o) && matchedAt(inA, i, ia) && matchedAt(inB, i, ib) {
+			out.WriteString(o[i])
+			i, ia, ib = i+1, ia+1, ib+1
+			continue
+		}
+		// the chunk up to the next base line kept in both sides changed in
+		// at least one of them.
+		end, endA, endB := i, len(a), len(b)
+		for ; end < len(o); end++ {
+			ja, okA := inA[end]
+			jb, okB := inB[end]
+			if okA && okB {
+				endA, endB = ja, jb
+				break
+			}
+		}
+		if end == i && endA == ia && endB == ib {
+			return out.Bytes(), conflicts
+		}
+		chunkO, chunkA, chunkB := o[i:end], a[ia:endA], b[ib:endB]
+		switch {
+		case equal(chunkO, chunkA):
+			write(chunkB)
+		case equal(chunkO, chunkB), equal(chunkA, chunkB):
+			write(chunkA)
+		default:
+			conflicts = true
+			out.WriteString(markerMine + " " + mineLabel + "\n")
+			write(chunkA)
+			out.WriteString(markerSep + "\n")
+			write(chunkB)
+			out.WriteString(markerTheirs + " " + theirsLabel + "\n")
+		}
+		i, ia, ib = end, endA, endB
+	}
+}
+
+// HasConflictMarkers reports whether content still has the conflict markers
+// of an unresolved merge.
+func HasConflictMarkers(content []byte) bool {
+	var mine, theirs bool
+	for _, line := range splitLines(content) {
+		mine = mine || strings.HasPrefix(line, markerMine+" ")
+		theirs = theirs || strings.HasPrefix(line, markerTheirs+" ")
+	}
+	return mine && theirs
+}
+
+// matches maps the lines of o kept in a to their index in a.
+func matches(o, a []string) map[int]int {
+	m := map[int]int{}
+	for _, block := range difflib.NewMatcherWithJunk(o, a, false, nil).GetMatchingBlocks() {
+		for k := 0; k < block.Size; k++ {
+			m[block.A+k] = block.B + k
+		}
+	}
+	return m
+}
+
+func matchedAt(m map[int]int, i, j int) bool {
+	k, ok := m[i]
+	return ok && k == j
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge3(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+	tests := []struct {
+		name          string
+		mine, theirs  string
+		want          string
+		wantConflicts bool
+	}{{
+		name:   "unchanged",
+		mine:   base,
+		theirs: base,
+		want:   base,
+	}, {
+		name:   "changes in different lines",
+		mine:   "a\nB\nc\nd\ne\n",
+		theirs: "a\nb\nc\nD\ne\nf\n",
+		want:   "a\nB\nc\nD\ne\nf\n",
+	}, {
+		name:   "same change on both sides",
+		mine:   "a\nb\nC\nd\ne\n",
+		theirs: "a\nb\nC\nd\ne\n",
+		want:   "a\nb\nC\nd\ne\n",
+	}, {
+		name:   "deleted and inserted lines",
+		mine:   "b\nc\nd\ne\n",
+		theirs: "a\nb\nc\nc2\nd\ne\n",
+		want:   "b\nc\nc2\nd\ne\n",
+	}, {
+		name:          "conflicting change",
+		mine:          "a\nb\nmine\nd\ne\n",
+		theirs:        "a\nb\ntheirs\nd\ne\n",
+		want:          "a\nb\n<<<<<<< mine\nmine\n=======\ntheirs\n>>>>>>> theirs\nd\ne\n",
+		wantConflicts: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, conflicts := Merge3([]byte(base), []byte(tc.mine), []byte(tc.theirs), "mine", "theirs")
+			assert.Equal(t, tc.want, string(got))
+			assert.Equal(t, tc.wantConflicts, conflicts)
+			assert.Equal(t, tc.wantConflicts, HasConflictMarkers(got))
+		})
+	}
+}
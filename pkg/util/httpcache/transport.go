@@ -0,0 +1,221 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpcache provides an http.RoundTripper that keeps GET responses
+// on disk and revalidates them with the ETag returned by the server.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// XFromCache is set on responses that were served from the disk cache.
+	XFromCache = "X-From-Cache"
+
+	// MaxBodySize is the size of the largest body stored, larger responses
+	// are streamed to the caller untouched.
+	MaxBodySize = 1 << 20
+)
+
+// Transport serves GET requests from Dir while the stored response is
+// younger than TTL. Older responses are revalidated with If-None-Match,
+// and any successful non GET request drops the whole directory since it
+// may have changed the listed resources.
+//
+// Only JSON bodies up to MaxBodySize are stored. Requests sent with
+// Cache-Control: no-cache, like the ones polling a resource or downloading
+// a file, always go to the server and are never stored.
+type Transport struct {
+	Dir       string
+	TTL       time.Duration
+	Transport http.RoundTripper
+}
+
+var _ http.RoundTripper = &Transport{}
+
+type entry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewTransport returns a Transport storing responses in dir and sending
+// requests through rt, or http.DefaultTransport when rt is nil.
+func NewTransport(dir string, ttl time.Duration, rt http.RoundTripper) *Transport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{
+		Dir:       dir,
+		TTL:       ttl,
+		Transport: rt,
+	}
+}
+
+// Clear removes every response stored under dir.
+func Clear(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		resp, err := t.Transport.RoundTrip(req)
+		if err == nil && req.Method != http.MethodHead && resp.StatusCode < http.StatusBadRequest {
+			_ = Clear(t.Dir)
+		}
+		return resp, err
+	}
+	if bypass(req) {
+		return t.Transport.RoundTrip(req)
+	}
+	path := t.path(req)
+	cached, modTime, err := t.load(path)
+	if err == nil && time.Since(modTime) < t.TTL {
+		return cached.response(req), nil
+	}
+	etag := ""
+	if cached != nil {
+		etag = cached.Header.Get("ETag")
+	}
+	if etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		_ = resp.Body.Close()
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return cached.response(req), nil
+	}
+	if resp.StatusCode != http.StatusOK || !storable(resp) {
+		return resp, nil
+	}
+	// the length is unknown for compressed responses, at most one byte
+	// more than stored is read to find out.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > MaxBodySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	_ = t.store(path, &entry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	return resp, nil
+}
+
+// bypass reports whether the caller asked for a response from the server.
+func bypass(req *http.Request) bool {
+	for _, v := range req.Header.Values("Cache-Control") {
+		if strings.Contains(v, "no-cache") || strings.Contains(v, "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+// storable reports whether the response is a JSON body small enough to be
+// stored, which the metadata and listings completions read are.
+func storable(resp *http.Response) bool {
+	if resp.ContentLength > MaxBodySize {
+		return false
+	}
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json")
+}
+
+func (t *Transport) path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.String() + "\x00" + req.Header.Get("Accept")))
+	return filepath.Join(t.Dir, hex.EncodeToString(sum[:]))
+}
+
+func (t *Transport) load(path string) (*entry, time.Time, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var e entry
+	if err = json.Unmarshal(b, &e); err != nil {
+		return nil, time.Time{}, err
+	}
+	return &e, fi.ModTime(), nil
+}
+
+func (t *Transport) store(path string, e *entry) error {
+	if err := os.MkdirAll(t.Dir, 0o700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(t.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (e *entry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	header.Set(XFromCache, "1")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
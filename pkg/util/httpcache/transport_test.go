@@ -0,0 +1,151 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, "projects")
+	}))
+	defer server.Close()
+
+	transport := NewTransport(t.TempDir(), time.Minute, nil)
+	client := &http.Client{Transport: transport}
+	get := func() (string, string) {
+		resp, err := client.Get(server.URL + "/api/v4/projects?page=1")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return string(b), resp.Header.Get(XFromCache)
+	}
+
+	body, fromCache := get()
+	assert.Equal(t, "projects", body)
+	assert.Empty(t, fromCache)
+
+	// fresh entries are served without a request
+	body, fromCache = get()
+	assert.Equal(t, "projects", body)
+	assert.Equal(t, "1", fromCache)
+	assert.Equal(t, 1, requests)
+
+	// stale entries are revalidated with the etag
+	transport.TTL = 0
+	body, fromCache = get()
+	assert.Equal(t, "projects", body)
+	assert.Equal(t, "1", fromCache)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+
+	// writes drop the cache
+	resp, err := client.Post(server.URL+"/api/v4/projects", "application/json", nil)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	_, err = os.Stat(transport.Dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTransportSkips(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/api/v4/projects/1/pipelines/7":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"status":"running","poll":%d}`, requests)
+		case "/api/v4/projects/1/repository/archive":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = io.WriteString(w, "archive")
+		case "/api/v4/projects":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, strings.Repeat("x", MaxBodySize+10))
+		case "/api/v4/groups":
+			// flushed first, the length of the body is unknown.
+			w.Header().Set("Content-Type", "application/json")
+			w.(http.Flusher).Flush()
+			_, _ = io.WriteString(w, strings.Repeat("x", MaxBodySize+10))
+		}
+	}))
+	defer server.Close()
+
+	transport := NewTransport(t.TempDir(), time.Minute, nil)
+	client := &http.Client{Transport: transport}
+	get := func(path string, header http.Header) (string, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return string(b), resp.Header.Get(XFromCache)
+	}
+
+	// a polled resource is read from the server every time
+	noCache := http.Header{"Cache-Control": {"no-cache"}}
+	first, _ := get("/api/v4/projects/1/pipelines/7", noCache)
+	second, fromCache := get("/api/v4/projects/1/pipelines/7", noCache)
+	assert.NotEqual(t, first, second)
+	assert.Empty(t, fromCache)
+	assert.Equal(t, 2, requests)
+
+	// downloads are not stored
+	body, _ := get("/api/v4/projects/1/repository/archive", nil)
+	assert.Equal(t, "archive", body)
+	_, fromCache = get("/api/v4/projects/1/repository/archive", nil)
+	assert.Empty(t, fromCache)
+	assert.Equal(t, 4, requests)
+
+	// large bodies are streamed whole and not stored
+	body, _ = get("/api/v4/projects", nil)
+	assert.Len(t, body, MaxBodySize+10)
+	_, fromCache = get("/api/v4/projects", nil)
+	assert.Empty(t, fromCache)
+	assert.Equal(t, 6, requests)
+	body, _ = get("/api/v4/groups", nil)
+	assert.Len(t, body, MaxBodySize+10)
+	_, fromCache = get("/api/v4/groups", nil)
+	assert.Empty(t, fromCache)
+	assert.Equal(t, 8, requests)
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	barWidth   = 30
+	barRefresh = 100 * time.Millisecond
+)
+
+// Bar draws the progress of a transfer on a single line of w. It is an
+// io.Writer counting the bytes written to it, so it can be fed through
+// io.TeeReader or io.MultiWriter. Without a known total only the transferred
+// bytes and the rate are shown.
+type Bar struct {
+	w       io.Writer
+	text    string
+	total   int64
+	current int64
+	start   time.Time
+	drawn   time.Time
+}
+
+// NewBar creates a Bar for a transfer of total bytes, total is unknown when
+// it is not positive.
+func NewBar(w io.Writer, total int64, text string) *Bar {
+	return &Bar{
+		w:     w,
+		text:  text,
+		total: total,
+		start: time.Now(),
+	}
+}
+
+func (b *Bar) Write(p []byte) (int, error) {
+	b.current += int64(len(p))
+	if time.Since(b.drawn) >= barRefresh {
+		b.draw()
+	}
+	return len(p), nil
+}
+
+// Done draws the final state of the transfer and ends the line.
+func (b *Bar) Done() {
+	b.draw()
+	_, _ = fmt.Fprintln(b.w)
+}
+
+func (b *Bar) draw() {
+	b.drawn = time.Now()
+	rate := float64(b.current) / b.drawn.Sub(b.start).Seconds()
+	if b.total <= 0 {
+		_, _ = fmt.Fprintf(b.w, "\r%s %s %s/s", b.text, FormatBytes(b.current), FormatBytes(int64(rate)))
+		return
+	}
+	percent := b.current * 100 / b.total
+	filled := int(min(b.current*barWidth/b.total, barWidth))
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+	_, _ = fmt.Fprintf(b.w, "\r%s [%s] %s %s/%s %s/s",
+		b.text, SuccessColor(bar), CountColor("%3d%%", percent),
+		FormatBytes(b.current), FormatBytes(b.total), FormatBytes(int64(rate)))
+}
+
+// FormatBytes formats n bytes with a binary unit, such as 1.5 MiB.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBar(t *testing.T) {
+	NoColor()
+	out := &bytes.Buffer{}
+	bar := NewBar(out, 200, "archive.tar.gz")
+	_, err := bar.Write(make([]byte, 100))
+	assert.NoError(t, err)
+	_, err = bar.Write(make([]byte, 100))
+	assert.NoError(t, err)
+	bar.Done()
+	lines := strings.Split(out.String(), "\r")
+	last := lines[len(lines)-1]
+	assert.Contains(t, last, "archive.tar.gz ["+strings.Repeat("=", barWidth)+"] 100% 200 B/200 B")
+	assert.True(t, strings.HasSuffix(last, "\n"))
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	} {
+		assert.Equal(t, want, FormatBytes(n))
+	}
+}
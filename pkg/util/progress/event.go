@@ -33,6 +33,8 @@ const (
 	Warning
 	// Error means that the current task has errored
 	Error
+	// Pending means that the current task has not started yet
+	Pending
 )
 
 // Event represents a progress event.
@@ -115,6 +117,7 @@ var (
 	spinnerDone    = "✔"
 	spinnerWarning = "!"
 	spinnerError   = "✘"
+	spinnerPending = "○"
 )
 
 func (e *Event) Spinner() string {
@@ -127,6 +130,8 @@ func (e *Event) Spinner() string {
 		return ErrorColor(spinnerError)
 	case Running:
 		return ""
+	case Pending:
+		return spinnerPending
 	default:
 		return CountColor(e.spinner.Suffix)
 	}
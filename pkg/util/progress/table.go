@@ -0,0 +1,145 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/briandowns/spinner"
+
+	"github.com/huhouhua/glctl/pkg/util/term"
+)
+
+// Row is a line of a Table, led by the spinner of its status.
+type Row struct {
+	// Key identifies the row across draws.
+	Key     string
+	Status  EventStatus
+	Columns []string
+	// Detail is shown as the last column, such as a running time, a change
+	// of it alone does not print the row again outside of a terminal.
+	Detail string
+}
+
+// Table draws rows of aligned columns, each led by a spinner while its task
+// runs and by the mark of its final status once done. On a terminal the
+// table is redrawn in place, elsewhere a row is only printed when it
+// changes, so the output stays readable in a log.
+type Table struct {
+	w       io.Writer
+	header  []string
+	tty     bool
+	frames  []string
+	frame   int
+	lines   int
+	printed map[string]string
+}
+
+// NewTable creates a Table writing to w under the column names of header.
+func NewTable(w io.Writer, header ...string) *Table {
+	return &Table{
+		w:       w,
+		header:  header,
+		tty:     term.IsTerminal(w),
+		frames:  spinner.CharSets[11],
+		printed: map[string]string{},
+	}
+}
+
+// Draw draws the rows, the spinners move one frame every draw.
+func (t *Table) Draw(rows []Row) error {
+	t.frame = (t.frame + 1) % len(t.frames)
+	if !t.tty {
+		return t.print(rows)
+	}
+	lines, err := t.align(rows)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if t.lines > 0 {
+		// move back to the first line of the previous draw and clear it all
+		_, _ = fmt.Fprintf(&buf, "\x1b[%dA\r\x1b[J", t.lines)
+	}
+	if len(t.header) > 0 {
+		_, _ = fmt.Fprintf(&buf, "  %s\n", lines[0])
+		lines = lines[1:]
+	}
+	for i, row := range rows {
+		_, _ = fmt.Fprintf(&buf, "%s %s\n", t.mark(row.Status, t.frames[t.frame]), lines[i])
+	}
+	t.lines = strings.Count(buf.String(), "\n")
+	_, err = t.w.Write(buf.Bytes())
+	return err
+}
+
+// align returns the header and the columns of the rows as lines of aligned
+// columns, the marks are left out as their colors would break the alignment.
+func (t *Table) align(rows []Row) ([]string, error) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	if len(t.header) > 0 {
+		_, _ = fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	}
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(tw, strings.Join(append(row.Columns, row.Detail), "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines, nil
+}
+
+// print writes the rows that changed since the previous draw, a running row
+// keeps the first frame of the spinner so that it is not printed again.
+func (t *Table) print(rows []Row) error {
+	for _, row := range rows {
+		line := fmt.Sprintf("%s %s", t.mark(row.Status, t.frames[0]), strings.Join(row.Columns, "  "))
+		if t.printed[row.Key] == line {
+			continue
+		}
+		t.printed[row.Key] = line
+		if row.Detail != "" {
+			line += "  " + row.Detail
+		}
+		if _, err := fmt.Fprintln(t.w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) mark(status EventStatus, frame string) string {
+	switch status {
+	case Running:
+		return SuccessColor(frame)
+	case Done:
+		return SuccessColor(spinnerDone)
+	case Warning:
+		return WarningColor(spinnerWarning)
+	case Error:
+		return ErrorColor(spinnerError)
+	default:
+		return spinnerPending
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Kevin Berger <huhouhuam@gmail.com> Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTablePrintsChangedRows(t *testing.T) {
+	NoColor()
+	out := &bytes.Buffer{}
+	table := NewTable(out, "STAGE", "JOB", "STATUS")
+	build := Row{Key: "1", Status: Running, Columns: []string{"build", "compile", "running"}, Detail: "3s"}
+	test := Row{Key: "2", Status: Pending, Columns: []string{"test", "rspec", "created"}}
+	assert.NoError(t, table.Draw([]Row{build, test}))
+	build.Detail = "4s"
+	assert.NoError(t, table.Draw([]Row{build, test}))
+	build.Status, build.Columns[2] = Done, "success"
+	assert.NoError(t, table.Draw([]Row{build, test}))
+	assert.Equal(t, "⣾ build  compile  running  3s\n"+
+		"○ test  rspec  created\n"+
+		"✔ build  compile  success  4s\n", out.String())
+}
+
+func TestTableRedrawsInPlace(t *testing.T) {
+	NoColor()
+	out := &bytes.Buffer{}
+	table := NewTable(out, "JOB", "STATUS", "TIME")
+	table.tty = true
+	rows := []Row{
+		{Key: "1", Status: Error, Columns: []string{"compile", "failed"}},
+		{Key: "2", Status: Warning, Columns: []string{"lint-everything", "canceled"}, Detail: "1m"},
+	}
+	assert.NoError(t, table.Draw(rows))
+	first := "  JOB              STATUS    TIME\n" +
+		"✘ compile          failed\n" +
+		"! lint-everything  canceled  1m\n"
+	assert.Equal(t, first, out.String())
+	out.Reset()
+	assert.NoError(t, table.Draw(rows))
+	assert.Equal(t, "\x1b[3A\r\x1b[J"+first, out.String())
+}
@@ -65,6 +65,12 @@ func NewResponsiveWriter(w io.Writer) io.Writer {
 	return NewWordWrapWriter(w, limit)
 }
 
+// IsTerminal reports whether w writes to a terminal.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(file.Fd())
+}
+
 // NewWordWrapWriter is a Writer that supports a limit of characters on every line
 // and does auto word wrapping that respects that limit.
 func NewWordWrapWriter(w io.Writer, limit uint) io.Writer {